@@ -0,0 +1,320 @@
+package lndclient
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// EntryCategory is the category of a ledger entry.
+type EntryCategory uint8
+
+const (
+	// EntryCategoryOnChain represents funds that our wallet received or
+	// sent on chain, excluding fees.
+	EntryCategoryOnChain EntryCategory = iota
+
+	// EntryCategoryOnChainFee represents the fees our wallet paid for an
+	// on chain transaction.
+	EntryCategoryOnChainFee
+
+	// EntryCategoryInvoice represents a settled invoice.
+	EntryCategoryInvoice
+
+	// EntryCategoryPayment represents a successful payment, excluding
+	// fees.
+	EntryCategoryPayment
+
+	// EntryCategoryPaymentFee represents the routing fees paid for a
+	// successful payment.
+	EntryCategoryPaymentFee
+
+	// EntryCategoryForward represents the fees earned for forwarding a
+	// payment.
+	EntryCategoryForward
+)
+
+// String returns the string representation of an entry category.
+func (e EntryCategory) String() string {
+	switch e {
+	case EntryCategoryOnChain:
+		return "OnChain"
+
+	case EntryCategoryOnChainFee:
+		return "OnChainFee"
+
+	case EntryCategoryInvoice:
+		return "Invoice"
+
+	case EntryCategoryPayment:
+		return "Payment"
+
+	case EntryCategoryPaymentFee:
+		return "PaymentFee"
+
+	case EntryCategoryForward:
+		return "Forward"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// LedgerEntry is a single balance change of our node.
+type LedgerEntry struct {
+	// Timestamp is the time the balance change happened.
+	Timestamp time.Time
+
+	// Category is the kind of balance change.
+	Category EntryCategory
+
+	// AmountMsat is the balance change in millisatoshis. It is positive
+	// for incoming and negative for outgoing funds.
+	AmountMsat int64
+
+	// Reference identifies the source of the entry: the transaction hash
+	// for on chain entries, the payment hash for invoices and payments
+	// and the incoming and outgoing channel IDs for forwards.
+	Reference string
+
+	// Note is the label of a transaction or the memo of an invoice.
+	Note string
+}
+
+// LedgerEntries merges the on chain transactions, settled invoices,
+// successful payments and forwards of our node in the period between start
+// (inclusive) and end (exclusive) into a list of ledger entries, ordered by
+// time. Only confirmed transactions are included.
+func LedgerEntries(ctx context.Context, client LightningClient, start,
+	end time.Time) ([]LedgerEntry, error) {
+
+	inPeriod := func(timestamp time.Time) bool {
+		return !timestamp.Before(start) && timestamp.Before(end)
+	}
+
+	var entries []LedgerEntry
+
+	txs, err := client.ListTransactions(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		// Unconfirmed transactions may still be double spent, so they
+		// don't belong in the ledger yet.
+		if tx.Confirmations <= 0 || !inPeriod(tx.Timestamp) {
+			continue
+		}
+
+		entries = append(entries, transactionEntries(tx)...)
+	}
+
+	err = ForEachInvoice(ctx, client, ListInvoicesRequest{},
+		func(invoice Invoice) error {
+			if invoice.State != channeldb.ContractSettled ||
+				!inPeriod(invoice.SettleDate) {
+
+				return nil
+			}
+
+			entries = append(entries, LedgerEntry{
+				Timestamp:  invoice.SettleDate,
+				Category:   EntryCategoryInvoice,
+				AmountMsat: int64(invoice.AmountPaid),
+				Reference:  invoice.Hash.String(),
+				Note:       invoice.Memo,
+			})
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ForEachPayment(ctx, client, ListPaymentsRequest{},
+		func(payment Payment) error {
+			status := payment.Status
+			if status == nil ||
+				status.State != lnrpc.Payment_SUCCEEDED {
+
+				return nil
+			}
+
+			timestamp := paymentSettleTime(payment)
+			if !inPeriod(timestamp) {
+				return nil
+			}
+
+			entries = append(entries, paymentEntries(
+				payment, timestamp,
+			)...)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ForEachForwardingEvent(ctx, client, ForwardingHistoryRequest{
+		StartTime: start,
+		EndTime:   end,
+	}, func(event ForwardingEvent) error {
+		if !inPeriod(event.Timestamp) {
+			return nil
+		}
+
+		entries = append(entries, LedgerEntry{
+			Timestamp:  event.Timestamp,
+			Category:   EntryCategoryForward,
+			AmountMsat: int64(event.FeeMsat),
+			Reference: fmt.Sprintf("%v:%v", event.ChannelIn,
+				event.ChannelOut),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+
+	return entries, nil
+}
+
+// transactionEntries returns the ledger entries of an on chain transaction.
+// The balance change lnd reports for a transaction we sent includes the fee,
+// so we split it into the amount sent and the fee.
+func transactionEntries(tx Transaction) []LedgerEntry {
+	amount := tx.Amount
+	if amount < 0 {
+		amount += tx.Fee
+	}
+
+	entries := []LedgerEntry{{
+		Timestamp:  tx.Timestamp,
+		Category:   EntryCategoryOnChain,
+		AmountMsat: int64(lnwire.NewMSatFromSatoshis(amount)),
+		Reference:  tx.TxHash,
+		Note:       tx.Label,
+	}}
+
+	if tx.Amount < 0 && tx.Fee > 0 {
+		entries = append(entries, LedgerEntry{
+			Timestamp:  tx.Timestamp,
+			Category:   EntryCategoryOnChainFee,
+			AmountMsat: -int64(lnwire.NewMSatFromSatoshis(tx.Fee)),
+			Reference:  tx.TxHash,
+			Note:       tx.Label,
+		})
+	}
+
+	return entries
+}
+
+// paymentEntries returns the ledger entries of a successful payment.
+func paymentEntries(payment Payment, timestamp time.Time) []LedgerEntry {
+	entries := []LedgerEntry{{
+		Timestamp:  timestamp,
+		Category:   EntryCategoryPayment,
+		AmountMsat: -int64(payment.Amount),
+		Reference:  payment.Hash.String(),
+	}}
+
+	if payment.Fee > 0 {
+		entries = append(entries, LedgerEntry{
+			Timestamp:  timestamp,
+			Category:   EntryCategoryPaymentFee,
+			AmountMsat: -int64(payment.Fee),
+			Reference:  payment.Hash.String(),
+		})
+	}
+
+	return entries
+}
+
+// paymentSettleTime returns the time the last successful htlc of a payment
+// was settled, which is when the payment succeeded.
+func paymentSettleTime(payment Payment) time.Time {
+	var settleTime int64
+	for _, htlc := range payment.Htlcs {
+		if htlc.Status != lnrpc.HTLCAttempt_SUCCEEDED {
+			continue
+		}
+
+		if htlc.ResolveTimeNs > settleTime {
+			settleTime = htlc.ResolveTimeNs
+		}
+	}
+
+	return time.Unix(0, settleTime)
+}
+
+// ledgerHeader is the header row of the csv ledger format.
+var ledgerHeader = []string{
+	"timestamp", "category", "amount_msat", "reference", "note",
+}
+
+// WriteLedgerCSV writes the ledger entries to the writer in csv format, with
+// a header row and timestamps in RFC 3339 format.
+func WriteLedgerCSV(w io.Writer, entries []LedgerEntry) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(ledgerHeader); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err := csvWriter.Write([]string{
+			entry.Timestamp.UTC().Format(time.RFC3339Nano),
+			entry.Category.String(),
+			strconv.FormatInt(entry.AmountMsat, 10),
+			entry.Reference,
+			entry.Note,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// jsonLedgerEntry is the json representation of a ledger entry.
+type jsonLedgerEntry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Category   string    `json:"category"`
+	AmountMsat int64     `json:"amount_msat"`
+	Reference  string    `json:"reference"`
+	Note       string    `json:"note,omitempty"`
+}
+
+// WriteLedgerJSON writes the ledger entries to the writer as a json array.
+func WriteLedgerJSON(w io.Writer, entries []LedgerEntry) error {
+	jsonEntries := make([]jsonLedgerEntry, len(entries))
+	for i, entry := range entries {
+		jsonEntries[i] = jsonLedgerEntry{
+			Timestamp:  entry.Timestamp.UTC(),
+			Category:   entry.Category.String(),
+			AmountMsat: entry.AmountMsat,
+			Reference:  entry.Reference,
+			Note:       entry.Note,
+		}
+	}
+
+	return json.NewEncoder(w).Encode(jsonEntries)
+}
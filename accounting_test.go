@@ -0,0 +1,151 @@
+package lndclient
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcutil"
+)
+
+// TestTransactionEntries tests splitting on chain transactions into ledger
+// entries.
+func TestTransactionEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  btcutil.Amount
+		fee     btcutil.Amount
+		amounts []int64
+	}{
+		{
+			name:    "received",
+			amount:  1000,
+			amounts: []int64{1000000},
+		},
+		{
+			name:    "sent with fee",
+			amount:  -1100,
+			fee:     100,
+			amounts: []int64{-1000000, -100000},
+		},
+		{
+			name:    "sent without fee",
+			amount:  -1000,
+			amounts: []int64{-1000000},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			entries := transactionEntries(Transaction{
+				Amount: test.amount,
+				Fee:    test.fee,
+			})
+
+			if len(entries) != len(test.amounts) {
+				t.Fatalf("expected %v entries, got %v",
+					len(test.amounts), len(entries))
+			}
+
+			for i, entry := range entries {
+				amount := entry.AmountMsat
+				if amount != test.amounts[i] {
+					t.Fatalf("expected amount %v, got %v",
+						test.amounts[i], amount)
+				}
+			}
+		})
+	}
+}
+
+// ledgerClient is a lightning client that only has on chain transactions.
+type ledgerClient struct {
+	LightningClient
+
+	txs []Transaction
+}
+
+func (c *ledgerClient) ListTransactions(context.Context, int32, int32) (
+	[]Transaction, error) {
+
+	return c.txs, nil
+}
+
+func (c *ledgerClient) ListInvoices(context.Context, ListInvoicesRequest) (
+	*ListInvoicesResponse, error) {
+
+	return &ListInvoicesResponse{}, nil
+}
+
+func (c *ledgerClient) ListPayments(context.Context, ListPaymentsRequest) (
+	*ListPaymentsResponse, error) {
+
+	return &ListPaymentsResponse{}, nil
+}
+
+func (c *ledgerClient) ForwardingHistory(context.Context,
+	ForwardingHistoryRequest) (*ForwardingHistoryResponse, error) {
+
+	return &ForwardingHistoryResponse{}, nil
+}
+
+// TestLedgerEntriesUnconfirmed tests that unconfirmed transactions are left
+// out of the ledger.
+func TestLedgerEntriesUnconfirmed(t *testing.T) {
+	timestamp := time.Unix(1600000000, 0)
+	client := &ledgerClient{
+		txs: []Transaction{{
+			TxHash:        "confirmed",
+			Amount:        1000,
+			Timestamp:     timestamp,
+			Confirmations: 1,
+		}, {
+			TxHash:    "unconfirmed",
+			Amount:    2000,
+			Timestamp: timestamp,
+		}},
+	}
+
+	entries, err := LedgerEntries(
+		context.Background(), client, timestamp,
+		timestamp.Add(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("unable to get ledger entries: %v", err)
+	}
+
+	if len(entries) != 1 || entries[0].Reference != "confirmed" {
+		t.Fatalf("expected only the confirmed transaction, got %v",
+			entries)
+	}
+}
+
+// TestWriteLedgerCSV tests the csv format of the ledger.
+func TestWriteLedgerCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteLedgerCSV(&buf, []LedgerEntry{{
+		Timestamp:  time.Unix(1600000000, 0),
+		Category:   EntryCategoryForward,
+		AmountMsat: 1500,
+		Reference:  "1:2",
+	}, {
+		Timestamp:  time.Unix(1600000001, 0),
+		Category:   EntryCategoryInvoice,
+		AmountMsat: 2000,
+		Reference:  "hash",
+		Note:       "coffee, large",
+	}})
+	if err != nil {
+		t.Fatalf("unable to write csv: %v", err)
+	}
+
+	expected := "timestamp,category,amount_msat,reference,note\n" +
+		"2020-09-13T12:26:40Z,Forward,1500,1:2,\n" +
+		"2020-09-13T12:26:41Z,Invoice,2000,hash,\"coffee, large\"\n"
+	if buf.String() != expected {
+		t.Fatalf("expected:\n%v\ngot:\n%v", expected, buf.String())
+	}
+}
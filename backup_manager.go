@@ -0,0 +1,338 @@
+package lndclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// backupPrefix and backupSuffix enclose the names of the backups
+	// written by the backup manager.
+	backupPrefix = "channel-backup-"
+	backupSuffix = ".backup"
+
+	// backupTimeFormat is the format of the creation time in backup
+	// names. It sorts lexicographically in chronological order.
+	backupTimeFormat = "20060102T150405.000000000Z"
+)
+
+var (
+	// ErrBackupCorrupted is returned if a stored backup doesn't match the
+	// checksum it was stored with.
+	ErrBackupCorrupted = errors.New("channel backup corrupted")
+
+	// ErrNoBackup is returned if the storage doesn't hold any backup.
+	ErrNoBackup = errors.New("no channel backup found")
+)
+
+// BackupStorage is a storage backend for channel backups, such as a local
+// directory or an S3 compatible object store. Names are flat, opaque keys.
+type BackupStorage interface {
+	// Store stores the data under the given name, replacing any data that
+	// is already stored under it.
+	Store(ctx context.Context, name string, data []byte) error
+
+	// Load returns the data stored under the given name.
+	Load(ctx context.Context, name string) ([]byte, error)
+
+	// List returns the names of all stored entries.
+	List(ctx context.Context) ([]string, error)
+
+	// Delete removes the data stored under the given name.
+	Delete(ctx context.Context, name string) error
+}
+
+// FileBackupStorage is a BackupStorage that stores backups as files in a
+// local directory.
+type FileBackupStorage struct {
+	dir string
+}
+
+// A compile-time constraint to ensure FileBackupStorage satisfies the
+// BackupStorage interface.
+var _ BackupStorage = (*FileBackupStorage)(nil)
+
+// NewFileBackupStorage returns a storage that keeps backups in the given
+// directory, which is created if it doesn't exist.
+func NewFileBackupStorage(dir string) (*FileBackupStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileBackupStorage{
+		dir: dir,
+	}, nil
+}
+
+// Store writes the data to a temporary file first and then moves it in place,
+// so that an interrupted write never leaves a partial backup behind.
+func (f *FileBackupStorage) Store(_ context.Context, name string,
+	data []byte) error {
+
+	tmpFile := filepath.Join(f.dir, name+".tmp")
+	if err := ioutil.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, filepath.Join(f.dir, name))
+}
+
+// Load reads the data stored under the given name.
+func (f *FileBackupStorage) Load(_ context.Context, name string) ([]byte,
+	error) {
+
+	return ioutil.ReadFile(filepath.Join(f.dir, name))
+}
+
+// List returns the names of all files in the directory.
+func (f *FileBackupStorage) List(_ context.Context) ([]string, error) {
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		names = append(names, file.Name())
+	}
+
+	return names, nil
+}
+
+// Delete removes the file stored under the given name.
+func (f *FileBackupStorage) Delete(_ context.Context, name string) error {
+	return os.Remove(filepath.Join(f.dir, name))
+}
+
+// BackupManager keeps the channel backups of lnd in a BackupStorage. It
+// subscribes to backup updates, verifies every backup with lnd before storing
+// it, checks that it was stored correctly and removes old backups, keeping
+// only a configured number of the most recent ones.
+type BackupManager struct {
+	client  LightningClient
+	storage BackupStorage
+	keep    int
+
+	// mu protects lastHash.
+	mu sync.Mutex
+
+	// lastHash is the hash of the backup that was stored last, which is
+	// used to skip storing unchanged backups.
+	lastHash [sha256.Size]byte
+}
+
+// NewBackupManager returns a backup manager that stores backups in the given
+// storage and keeps the given number of the most recent backups. If keep is
+// zero, no backups are ever removed.
+func NewBackupManager(client LightningClient, storage BackupStorage,
+	keep int) *BackupManager {
+
+	return &BackupManager{
+		client:  client,
+		storage: storage,
+		keep:    keep,
+	}
+}
+
+// Run stores the current backup and then every backup update until the
+// context is canceled or an error occurs.
+func (b *BackupManager) Run(ctx context.Context) error {
+	// We subscribe before exporting the current backup, so we don't miss
+	// updates in between.
+	backups, errChan, err := b.client.SubscribeChannelBackups(ctx)
+	if err != nil {
+		return err
+	}
+
+	backup, err := b.client.ChannelBackups(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Backup(ctx, backup); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case backup := <-backups:
+			if err := b.Backup(ctx, backup); err != nil {
+				return err
+			}
+
+		case err := <-errChan:
+			return err
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Backup verifies the encrypted chanbackup.Multi payload with lnd, stores it
+// and removes old backups. Backups that are identical to the last stored one
+// are skipped.
+func (b *BackupManager) Backup(ctx context.Context, backup []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hash := sha256.Sum256(backup)
+	if hash == b.lastHash {
+		return nil
+	}
+
+	if err := b.client.VerifyChannelBackups(ctx, backup); err != nil {
+		return fmt.Errorf("invalid channel backup: %v", err)
+	}
+
+	name := backupName(time.Now(), hash)
+	if err := b.storage.Store(ctx, name, backup); err != nil {
+		return err
+	}
+
+	// Read the backup back to make sure the storage holds what we wrote.
+	if _, err := b.load(ctx, name); err != nil {
+		return err
+	}
+	b.lastHash = hash
+
+	return b.rotate(ctx)
+}
+
+// Latest returns the most recent stored backup, after checking its
+// integrity.
+func (b *BackupManager) Latest(ctx context.Context) ([]byte, error) {
+	names, err := b.backups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, ErrNoBackup
+	}
+
+	return b.load(ctx, names[len(names)-1])
+}
+
+// load loads the backup with the given name and checks it against the
+// checksum in its name.
+func (b *BackupManager) load(ctx context.Context, name string) ([]byte,
+	error) {
+
+	backup, err := b.storage.Load(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	_, hash, err := parseBackupName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := sha256.Sum256(backup)
+	if !bytes.Equal(actual[:], hash) {
+		return nil, ErrBackupCorrupted
+	}
+
+	return backup, nil
+}
+
+// rotate removes the oldest backups that exceed the number of backups to
+// keep.
+func (b *BackupManager) rotate(ctx context.Context) error {
+	if b.keep == 0 {
+		return nil
+	}
+
+	names, err := b.backups(ctx)
+	if err != nil {
+		return err
+	}
+
+	for len(names) > b.keep {
+		if err := b.storage.Delete(ctx, names[0]); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+
+	return nil
+}
+
+// backups returns the names of all stored backups from oldest to newest.
+// Entries of the storage that aren't backups are ignored.
+func (b *BackupManager) backups(ctx context.Context) ([]string, error) {
+	names, err := b.storage.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, name := range names {
+		if _, _, err := parseBackupName(name); err != nil {
+			continue
+		}
+
+		backups = append(backups, name)
+	}
+	sort.Strings(backups)
+
+	return backups, nil
+}
+
+// backupName returns the name of a backup that was created at the given time
+// and has the given hash.
+func backupName(created time.Time, hash [sha256.Size]byte) string {
+	return fmt.Sprintf("%s%s-%x%s", backupPrefix,
+		created.UTC().Format(backupTimeFormat), hash[:], backupSuffix)
+}
+
+// parseBackupName returns the creation time and hash encoded in a backup
+// name.
+func parseBackupName(name string) (time.Time, []byte, error) {
+	if !strings.HasPrefix(name, backupPrefix) ||
+		!strings.HasSuffix(name, backupSuffix) {
+
+		return time.Time{}, nil, fmt.Errorf("not a backup: %v", name)
+	}
+
+	name = strings.TrimSuffix(
+		strings.TrimPrefix(name, backupPrefix), backupSuffix,
+	)
+	parts := strings.Split(name, "-")
+	if len(parts) != 2 {
+		return time.Time{}, nil, fmt.Errorf("not a backup: %v", name)
+	}
+
+	created, err := time.Parse(backupTimeFormat, parts[0])
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	hash, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	if len(hash) != sha256.Size {
+		return time.Time{}, nil, fmt.Errorf("invalid backup hash: %x",
+			hash)
+	}
+
+	return created, hash, nil
+}
@@ -0,0 +1,75 @@
+package lndclient
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// verifyingClient is a lightning client that accepts all channel backups.
+type verifyingClient struct {
+	LightningClient
+}
+
+func (v *verifyingClient) VerifyChannelBackups(context.Context,
+	[]byte) error {
+
+	return nil
+}
+
+// TestBackupManager tests that backups are stored, rotated and checked for
+// integrity.
+func TestBackupManager(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backups")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	storage, err := NewFileBackupStorage(dir)
+	if err != nil {
+		t.Fatalf("unable to create storage: %v", err)
+	}
+
+	ctx := context.Background()
+	manager := NewBackupManager(&verifyingClient{}, storage, 2)
+
+	if _, err := manager.Latest(ctx); err != ErrNoBackup {
+		t.Fatalf("expected no backup, got: %v", err)
+	}
+
+	for _, backup := range []string{"a", "b", "b", "c"} {
+		if err := manager.Backup(ctx, []byte(backup)); err != nil {
+			t.Fatalf("unable to store backup: %v", err)
+		}
+	}
+
+	// The duplicate is skipped and only the two most recent backups are
+	// kept.
+	names, err := manager.backups(ctx)
+	if err != nil {
+		t.Fatalf("unable to list backups: %v", err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("expected 2 backups, got %v", len(names))
+	}
+
+	latest, err := manager.Latest(ctx)
+	if err != nil {
+		t.Fatalf("unable to load backup: %v", err)
+	}
+	if string(latest) != "c" {
+		t.Fatalf("expected latest backup c, got %s", latest)
+	}
+
+	// Tampering with the stored backup is detected.
+	path := filepath.Join(dir, names[1])
+	if err := ioutil.WriteFile(path, []byte("x"), 0600); err != nil {
+		t.Fatalf("unable to write backup: %v", err)
+	}
+	if _, err := manager.Latest(ctx); err != ErrBackupCorrupted {
+		t.Fatalf("expected corrupted backup, got: %v", err)
+	}
+}
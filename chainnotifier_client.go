@@ -17,29 +17,78 @@ type ChainNotifierClient interface {
 	RegisterBlockEpochNtfn(ctx context.Context) (
 		chan int32, chan error, error)
 
+	// RegisterBlockEpochs registers for notifications of new blocks,
+	// delivering both the height and the hash of every block.
+	RegisterBlockEpochs(ctx context.Context) (chan BlockEpoch, chan error,
+		error)
+
+	// RegisterConfirmationsNtfn registers for a notification once the
+	// given transaction or script reaches the requested number of
+	// confirmations. Reorgs are only reported if a reorg channel is
+	// provided through the WithReOrgChan option.
 	RegisterConfirmationsNtfn(ctx context.Context, txid *chainhash.Hash,
-		pkScript []byte, numConfs, heightHint int32) (
-		chan *chainntnfs.TxConfirmation, chan error, error)
+		pkScript []byte, numConfs, heightHint int32,
+		opts ...NotifierOption) (chan *chainntnfs.TxConfirmation,
+		chan error, error)
 
 	RegisterSpendNtfn(ctx context.Context,
 		outpoint *wire.OutPoint, pkScript []byte, heightHint int32) (
 		chan *chainntnfs.SpendDetail, chan error, error)
 }
 
+// BlockEpoch identifies a block of the main chain.
+type BlockEpoch struct {
+	// Height is the height of the block.
+	Height int32
+
+	// Hash is the hash of the block.
+	Hash chainhash.Hash
+}
+
+// notifierOptions is a set of functional options that allow callers to
+// further modify the type of chain event notifications they receive.
+type notifierOptions struct {
+	// reOrgChan, if set, will be sent on once a reorg of the confirmed
+	// transaction is detected.
+	reOrgChan chan struct{}
+}
+
+// NotifierOption is a functional option that allows a caller to modify the
+// events received from the notifier.
+type NotifierOption func(*notifierOptions)
+
+// WithReOrgChan configures a channel that will be sent on if the confirmed
+// transaction is reorged out of the chain. After a reorg, the confirmation
+// is sent again once the transaction reconfirms, so the confirmation
+// channel is not closed.
+func WithReOrgChan(reOrgChan chan struct{}) NotifierOption {
+	return func(o *notifierOptions) {
+		o.reOrgChan = reOrgChan
+	}
+}
+
 type chainNotifierClient struct {
 	client   chainrpc.ChainNotifierClient
 	chainMac serializedMacaroon
 
-	wg sync.WaitGroup
+	wg   sync.WaitGroup
+	quit chan struct{}
 }
 
 func newChainNotifierClient(conn *grpc.ClientConn, chainMac serializedMacaroon) *chainNotifierClient {
 	return &chainNotifierClient{
 		client:   chainrpc.NewChainNotifierClient(conn),
 		chainMac: chainMac,
+		quit:     make(chan struct{}),
 	}
 }
 
+// stop signals all goroutines of the client to exit, even if they are
+// blocked delivering updates to a caller that stopped reading.
+func (s *chainNotifierClient) stop() {
+	close(s.quit)
+}
+
 func (s *chainNotifierClient) WaitForFinished() {
 	s.wg.Wait()
 }
@@ -120,9 +169,20 @@ func (s *chainNotifierClient) RegisterSpendNtfn(ctx context.Context,
 	return spendChan, errChan, nil
 }
 
+// RegisterConfirmationsNtfn registers for a notification once the given
+// transaction or script reaches the requested number of confirmations. Reorgs
+// are only reported if a reorg channel is provided through the WithReOrgChan
+// option. In that case the notification stays active after the confirmation,
+// so that a reorg and any subsequent reconfirmation can be delivered.
 func (s *chainNotifierClient) RegisterConfirmationsNtfn(ctx context.Context,
-	txid *chainhash.Hash, pkScript []byte, numConfs, heightHint int32) (
-	chan *chainntnfs.TxConfirmation, chan error, error) {
+	txid *chainhash.Hash, pkScript []byte, numConfs, heightHint int32,
+	opts ...NotifierOption) (chan *chainntnfs.TxConfirmation, chan error,
+	error) {
+
+	var options notifierOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 
 	var txidSlice []byte
 	if txid != nil {
@@ -172,17 +232,42 @@ func (s *chainNotifierClient) RegisterConfirmationsNtfn(ctx context.Context,
 					errChan <- err
 					return
 				}
-				confChan <- &chainntnfs.TxConfirmation{
+				conf := &chainntnfs.TxConfirmation{
 					BlockHeight: c.Conf.BlockHeight,
 					BlockHash:   blockHash,
 					Tx:          tx,
 					TxIndex:     c.Conf.TxIndex,
 				}
-				return
 
-			// Ignore reorg events, not supported.
+				// If the caller isn't interested in reorgs,
+				// the first confirmation is all we deliver.
+				if options.reOrgChan == nil {
+					confChan <- conf
+					return
+				}
+
+				select {
+				case confChan <- conf:
+				case <-ctx.Done():
+					return
+				case <-s.quit:
+					return
+				}
+
+			// Ignore reorg events if no reorg channel was
+			// provided.
 			case *chainrpc.ConfEvent_Reorg:
-				continue
+				if options.reOrgChan == nil {
+					continue
+				}
+
+				select {
+				case options.reOrgChan <- struct{}{}:
+				case <-ctx.Done():
+					return
+				case <-s.quit:
+					return
+				}
 
 			// Nil event, should never happen.
 			case nil:
@@ -205,15 +290,66 @@ func (s *chainNotifierClient) RegisterConfirmationsNtfn(ctx context.Context,
 func (s *chainNotifierClient) RegisterBlockEpochNtfn(ctx context.Context) (
 	chan int32, chan error, error) {
 
+	blockEpochChan := make(chan int32)
+	blockErrorChan, err := s.registerBlockEpochs(
+		ctx, func(epoch BlockEpoch) bool {
+			select {
+			case blockEpochChan <- epoch.Height:
+				return true
+			case <-ctx.Done():
+				return false
+			case <-s.quit:
+				return false
+			}
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blockEpochChan, blockErrorChan, nil
+}
+
+// RegisterBlockEpochs registers for notifications of new blocks. Unlike
+// RegisterBlockEpochNtfn, every notification carries the hash of the block
+// in addition to its height.
+func (s *chainNotifierClient) RegisterBlockEpochs(ctx context.Context) (
+	chan BlockEpoch, chan error, error) {
+
+	blockEpochChan := make(chan BlockEpoch)
+	blockErrorChan, err := s.registerBlockEpochs(
+		ctx, func(epoch BlockEpoch) bool {
+			select {
+			case blockEpochChan <- epoch:
+				return true
+			case <-ctx.Done():
+				return false
+			case <-s.quit:
+				return false
+			}
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blockEpochChan, blockErrorChan, nil
+}
+
+// registerBlockEpochs starts a block epoch subscription and passes every new
+// block to the deliver function until it returns false. Errors of the
+// subscription are delivered on the returned error channel.
+func (s *chainNotifierClient) registerBlockEpochs(ctx context.Context,
+	deliver func(BlockEpoch) bool) (chan error, error) {
+
 	blockEpochClient, err := s.client.RegisterBlockEpochNtfn(
 		s.chainMac.WithMacaroonAuth(ctx), &chainrpc.BlockEpoch{},
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	blockErrorChan := make(chan error, 1)
-	blockEpochChan := make(chan int32)
 
 	// Start block epoch goroutine.
 	s.wg.Add(1)
@@ -226,13 +362,20 @@ func (s *chainNotifierClient) RegisterBlockEpochNtfn(ctx context.Context) (
 				return
 			}
 
-			select {
-			case blockEpochChan <- int32(epoch.Height):
-			case <-ctx.Done():
+			hash, err := chainhash.NewHash(epoch.Hash)
+			if err != nil {
+				blockErrorChan <- err
+				return
+			}
+
+			if !deliver(BlockEpoch{
+				Height: int32(epoch.Height),
+				Hash:   *hash,
+			}) {
 				return
 			}
 		}
 	}()
 
-	return blockEpochChan, blockErrorChan, nil
+	return blockErrorChan, nil
 }
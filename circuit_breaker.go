@@ -0,0 +1,354 @@
+package lndclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// defaultCircuitBreakerWindow is the default period over which the circuit
+// breaker rate limits forwards and computes failure rates.
+const defaultCircuitBreakerWindow = 10 * time.Minute
+
+// maxPendingAge is the time after which a pending forward is assumed to be
+// resolved even if we missed its outcome. Htlcs can't be held longer than the
+// maximum cltv delta of 2016 blocks that lnd accepts.
+const maxPendingAge = 2016 * 10 * time.Minute
+
+// CircuitBreakerLimits are the limits that the circuit breaker enforces on
+// the forwards that arrive on a channel. Zero values disable a limit.
+type CircuitBreakerLimits struct {
+	// MaxPending is the maximum number of forwards from the channel that
+	// may be pending at the same time.
+	MaxPending int
+
+	// MaxForwards is the maximum number of forwards from the channel that
+	// are accepted per window.
+	MaxForwards int
+
+	// MaxFailureRate is the maximum fraction of the forwards from the
+	// channel that were resolved in the window that may have failed.
+	// While the failure rate is higher, new forwards are rejected until
+	// enough failures have left the window.
+	MaxFailureRate float64
+
+	// MinResolved is the number of forwards that need to be resolved in
+	// the window before the failure rate is enforced.
+	MinResolved int
+}
+
+// CircuitBreakerConfig contains the configuration of a circuit breaker.
+type CircuitBreakerConfig struct {
+	// Router is used to intercept forwards and to track their outcome.
+	Router RouterClient
+
+	// Window is the period over which forwards are rate limited and
+	// failure rates are computed. If zero, a default of ten minutes is
+	// used.
+	Window time.Duration
+
+	// DefaultLimits are the limits of channels that don't have limits
+	// set for them or their peer.
+	DefaultLimits CircuitBreakerLimits
+
+	// ChannelLimits are limits for specific incoming channels. They take
+	// precedence over peer limits.
+	ChannelLimits map[uint64]CircuitBreakerLimits
+
+	// PeerLimits are limits for the channels with specific peers. The
+	// limits apply to each channel with the peer individually. Peer
+	// limits require Peers to be set.
+	PeerLimits map[route.Vertex]CircuitBreakerLimits
+
+	// Peers maps channel IDs to the peer of the channel, as returned by
+	// ChannelPeers.
+	Peers map[uint64]route.Vertex
+}
+
+// circuitKey identifies an htlc by its incoming channel and htlc index.
+type circuitKey struct {
+	chanID uint64
+	htlcID uint64
+}
+
+// forwardResult is the outcome of a forward that was resolved.
+type forwardResult struct {
+	resolved time.Time
+	failed   bool
+}
+
+// channelState holds the forwarding activity of an incoming channel.
+type channelState struct {
+	// pending is the number of accepted forwards that aren't resolved yet.
+	pending int
+
+	// accepted holds the times of the forwards that were accepted in the
+	// window.
+	accepted []time.Time
+
+	// results holds the outcomes of the forwards that were resolved in the
+	// window.
+	results []forwardResult
+}
+
+// prune removes the activity that is older than the cutoff.
+func (c *channelState) prune(cutoff time.Time) {
+	for len(c.accepted) > 0 && c.accepted[0].Before(cutoff) {
+		c.accepted = c.accepted[1:]
+	}
+
+	for len(c.results) > 0 && c.results[0].resolved.Before(cutoff) {
+		c.results = c.results[1:]
+	}
+}
+
+// failureRate returns the fraction of the resolved forwards that failed.
+func (c *channelState) failureRate() float64 {
+	if len(c.results) == 0 {
+		return 0
+	}
+
+	var failed int
+	for _, result := range c.results {
+		if result.failed {
+			failed++
+		}
+	}
+
+	return float64(failed) / float64(len(c.results))
+}
+
+// CircuitBreaker protects a routing node from peers that flood it with
+// forwards or that send forwards that mostly fail, as used in channel jamming
+// attacks. It intercepts all forwards and rejects those that exceed the limits
+// of their incoming channel.
+type CircuitBreaker struct {
+	cfg    *CircuitBreakerConfig
+	window time.Duration
+
+	// now returns the current time. It is overridden in tests.
+	now func() time.Time
+
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	channels map[uint64]*channelState
+
+	// pending holds the time at which each pending forward was accepted.
+	pending map[circuitKey]time.Time
+}
+
+// NewCircuitBreaker returns a circuit breaker with the given configuration.
+func NewCircuitBreaker(cfg *CircuitBreakerConfig) *CircuitBreaker {
+	window := cfg.Window
+	if window == 0 {
+		window = defaultCircuitBreakerWindow
+	}
+
+	return &CircuitBreaker{
+		cfg:      cfg,
+		window:   window,
+		now:      time.Now,
+		channels: make(map[uint64]*channelState),
+		pending:  make(map[circuitKey]time.Time),
+	}
+}
+
+// Run intercepts forwards and tracks their outcome until the context is
+// canceled or an error occurs. Once it returns, forwards are no longer
+// limited.
+func (c *CircuitBreaker) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// We subscribe to htlc events before we start intercepting, so that
+	// we don't miss the outcome of any forward that we accept.
+	events, errChan, err := c.cfg.Router.SubscribeHtlcEvents(ctx)
+	if err != nil {
+		return err
+	}
+
+	interceptErr := make(chan error, 1)
+	go func() {
+		interceptErr <- c.cfg.Router.InterceptHtlcs(ctx, c.handleHtlc)
+	}()
+
+	pruneTicker := time.NewTicker(c.window)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			c.handleEvent(event)
+
+		case <-pruneTicker.C:
+			c.prune()
+
+		case err := <-errChan:
+			return err
+
+		case err := <-interceptErr:
+			return err
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// handleHtlc resumes the intercepted htlc if its incoming channel is within
+// its limits and fails it otherwise.
+func (c *CircuitBreaker) handleHtlc(_ context.Context,
+	htlc InterceptedHtlc) (*InterceptedHtlcResponse, error) {
+
+	key := circuitKey{
+		chanID: htlc.IncomingCircuitKey.ChanID.ToUint64(),
+		htlcID: htlc.IncomingCircuitKey.HtlcID,
+	}
+
+	action := InterceptorActionFail
+	if c.admit(key) {
+		action = InterceptorActionResume
+	}
+
+	return &InterceptedHtlcResponse{
+		Action: action,
+	}, nil
+}
+
+// admit returns true if the forward is within the limits of its incoming
+// channel, in which case it is tracked as pending.
+func (c *CircuitBreaker) admit(key circuitKey) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	state := c.channel(key.chanID)
+	state.prune(now.Add(-c.window))
+
+	limits := c.limits(key.chanID)
+	switch {
+	case limits.MaxPending > 0 && state.pending >= limits.MaxPending:
+		return false
+
+	case limits.MaxForwards > 0 &&
+		len(state.accepted) >= limits.MaxForwards:
+
+		return false
+
+	case limits.MaxFailureRate > 0 &&
+		len(state.results) >= limits.MinResolved &&
+		state.failureRate() > limits.MaxFailureRate:
+
+		return false
+	}
+
+	state.pending++
+	state.accepted = append(state.accepted, now)
+	c.pending[key] = now
+
+	return true
+}
+
+// handleEvent records the outcome of a forward that we accepted.
+func (c *CircuitBreaker) handleEvent(event *HtlcEvent) {
+	if event.EventType != routerrpc.HtlcEvent_FORWARD {
+		return
+	}
+
+	var failed bool
+	switch event.Outcome {
+	case HtlcEventOutcomeSettle:
+
+	case HtlcEventOutcomeForwardFail, HtlcEventOutcomeLinkFail:
+		failed = true
+
+	default:
+		return
+	}
+
+	c.resolve(circuitKey{
+		chanID: event.IncomingChannelID,
+		htlcID: event.IncomingHtlcID,
+	}, failed)
+}
+
+// resolve records the outcome of a pending forward. Forwards that weren't
+// accepted by us, for example because they were pending before we started,
+// are ignored.
+func (c *CircuitBreaker) resolve(key circuitKey, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.pending[key]; !ok {
+		return
+	}
+	delete(c.pending, key)
+
+	state := c.channel(key.chanID)
+	state.pending--
+	state.results = append(state.results, forwardResult{
+		resolved: c.now(),
+		failed:   failed,
+	})
+}
+
+// prune drops the state of channels that were idle for a whole window, so
+// that the memory use doesn't grow with every channel that ever forwarded to
+// us. Forwards that are pending for longer than any htlc can be held are
+// dropped too, we must have missed their outcome.
+func (c *CircuitBreaker) prune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, accepted := range c.pending {
+		if now.Sub(accepted) < maxPendingAge {
+			continue
+		}
+
+		delete(c.pending, key)
+		c.channel(key.chanID).pending--
+	}
+
+	cutoff := now.Add(-c.window)
+	for chanID, state := range c.channels {
+		state.prune(cutoff)
+
+		if state.pending == 0 && len(state.accepted) == 0 &&
+			len(state.results) == 0 {
+
+			delete(c.channels, chanID)
+		}
+	}
+}
+
+// channel returns the state of the channel, creating it if it doesn't exist
+// yet. The caller must hold the lock.
+func (c *CircuitBreaker) channel(chanID uint64) *channelState {
+	state, ok := c.channels[chanID]
+	if !ok {
+		state = &channelState{}
+		c.channels[chanID] = state
+	}
+
+	return state
+}
+
+// limits returns the limits that apply to the incoming channel.
+func (c *CircuitBreaker) limits(chanID uint64) CircuitBreakerLimits {
+	if limits, ok := c.cfg.ChannelLimits[chanID]; ok {
+		return limits
+	}
+
+	if peer, ok := c.cfg.Peers[chanID]; ok {
+		if limits, ok := c.cfg.PeerLimits[peer]; ok {
+			return limits
+		}
+	}
+
+	return c.cfg.DefaultLimits
+}
@@ -0,0 +1,154 @@
+package lndclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// TestCircuitBreaker tests that forwards are rejected once the limits of
+// their incoming channel are exceeded.
+func TestCircuitBreaker(t *testing.T) {
+	peer := route.Vertex{1}
+
+	breaker := NewCircuitBreaker(&CircuitBreakerConfig{
+		Window: time.Minute,
+		DefaultLimits: CircuitBreakerLimits{
+			MaxPending: 2,
+		},
+		PeerLimits: map[route.Vertex]CircuitBreakerLimits{
+			peer: {
+				MaxFailureRate: 0.5,
+				MinResolved:    2,
+			},
+		},
+		Peers: map[uint64]route.Vertex{2: peer},
+	})
+
+	now := time.Unix(1000, 0)
+	breaker.now = func() time.Time {
+		return now
+	}
+
+	resolve := func(key circuitKey, outcome HtlcEventOutcome) {
+		breaker.handleEvent(&HtlcEvent{
+			IncomingChannelID: key.chanID,
+			IncomingHtlcID:    key.htlcID,
+			EventType:         routerrpc.HtlcEvent_FORWARD,
+			Outcome:           outcome,
+		})
+	}
+
+	// Channel 1 uses the default limits, which only allow two pending
+	// forwards.
+	for i := uint64(0); i < 2; i++ {
+		if !breaker.admit(circuitKey{1, i}) {
+			t.Fatalf("expected forward %v to be admitted", i)
+		}
+	}
+	if breaker.admit(circuitKey{1, 2}) {
+		t.Fatalf("expected forward over pending limit to be rejected")
+	}
+
+	resolve(circuitKey{1, 0}, HtlcEventOutcomeSettle)
+	if !breaker.admit(circuitKey{1, 2}) {
+		t.Fatalf("expected forward to be admitted after resolution")
+	}
+
+	// Channel 2 uses the limits of its peer. After two failures out of
+	// three resolved forwards, it is cut off.
+	for i := uint64(0); i < 3; i++ {
+		if !breaker.admit(circuitKey{2, i}) {
+			t.Fatalf("expected forward %v to be admitted", i)
+		}
+	}
+	resolve(circuitKey{2, 0}, HtlcEventOutcomeSettle)
+	resolve(circuitKey{2, 1}, HtlcEventOutcomeLinkFail)
+	resolve(circuitKey{2, 2}, HtlcEventOutcomeForwardFail)
+
+	if breaker.admit(circuitKey{2, 3}) {
+		t.Fatalf("expected forward over failure rate to be rejected")
+	}
+
+	// Once the failures left the window, forwards are admitted again.
+	now = now.Add(2 * time.Minute)
+	if !breaker.admit(circuitKey{2, 3}) {
+		t.Fatalf("expected forward to be admitted after window")
+	}
+}
+
+// TestCircuitBreakerMinResolved tests that the failure rate is only enforced
+// once enough forwards were resolved in the window.
+func TestCircuitBreakerMinResolved(t *testing.T) {
+	breaker := NewCircuitBreaker(&CircuitBreakerConfig{
+		DefaultLimits: CircuitBreakerLimits{
+			MaxFailureRate: 0.5,
+			MinResolved:    3,
+		},
+	})
+
+	// Fail two forwards, which is a failure rate of one but fewer than
+	// the minimum number of resolved forwards.
+	for i := uint64(0); i < 2; i++ {
+		key := circuitKey{1, i}
+		if !breaker.admit(key) {
+			t.Fatalf("expected forward %v to be admitted", i)
+		}
+		breaker.resolve(key, true)
+	}
+
+	if !breaker.admit(circuitKey{1, 2}) {
+		t.Fatalf("expected forward below min resolved to be admitted")
+	}
+	breaker.resolve(circuitKey{1, 2}, true)
+
+	// With the third failure, the failure rate is enforced.
+	if breaker.admit(circuitKey{1, 3}) {
+		t.Fatalf("expected forward over failure rate to be rejected")
+	}
+}
+
+// TestCircuitBreakerPrune tests that the state of idle channels and of
+// forwards with a missed outcome is dropped.
+func TestCircuitBreakerPrune(t *testing.T) {
+	breaker := NewCircuitBreaker(&CircuitBreakerConfig{
+		Window: time.Minute,
+	})
+
+	now := time.Unix(1000, 0)
+	breaker.now = func() time.Time {
+		return now
+	}
+
+	// Channel 1 resolves its forward, channel 2 never does.
+	breaker.admit(circuitKey{1, 0})
+	breaker.resolve(circuitKey{1, 0}, false)
+	breaker.admit(circuitKey{2, 0})
+
+	// Within the window, the state of both channels is kept.
+	breaker.prune()
+	if len(breaker.channels) != 2 {
+		t.Fatalf("expected 2 channels, got %v", len(breaker.channels))
+	}
+
+	// Once the window passed, channel 1 is idle. Channel 2 still has a
+	// pending forward.
+	now = now.Add(2 * time.Minute)
+	breaker.prune()
+	if _, ok := breaker.channels[1]; ok {
+		t.Fatalf("expected idle channel to be pruned")
+	}
+	if _, ok := breaker.channels[2]; !ok {
+		t.Fatalf("expected channel with pending forward to be kept")
+	}
+
+	// After the maximum time a forward can be pending, the forward and
+	// with it the channel are dropped.
+	now = now.Add(maxPendingAge)
+	breaker.prune()
+	if len(breaker.channels) != 0 || len(breaker.pending) != 0 {
+		t.Fatalf("expected all state to be pruned")
+	}
+}
@@ -0,0 +1,153 @@
+package lndclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrInvoiceNotFound is returned if a call references an invoice that
+	// is not known to lnd.
+	ErrInvoiceNotFound = errors.New("invoice not found")
+
+	// ErrInvoiceAlreadyExists is returned when adding an invoice for a
+	// payment hash that lnd already has an invoice for.
+	ErrInvoiceAlreadyExists = errors.New("invoice already exists")
+
+	// ErrChannelNotFound is returned if a call references a channel that
+	// is not known to lnd.
+	ErrChannelNotFound = errors.New("channel not found")
+
+	// ErrInsufficientBalance is returned if the wallet doesn't have enough
+	// funds for a transaction or channel funding.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+
+	// ErrPermissionDenied is returned if lnd rejected the macaroon of a
+	// call, either because it is invalid or because it lacks the required
+	// permissions.
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrPaymentInFlight is returned if a payment to the same hash is
+	// already in progress.
+	ErrPaymentInFlight = errors.New("payment in flight")
+)
+
+// rpcErrorPatterns maps substrings of the error messages that lnd returns to
+// typed errors. lnd returns most of its errors with the code Unknown, so we
+// need to match the message.
+var rpcErrorPatterns = []struct {
+	substr string
+	kind   error
+}{
+	{"unable to locate invoice", ErrInvoiceNotFound},
+	{"there are no existing invoices", ErrInvoiceNotFound},
+	{"invoice with payment hash already exists", ErrInvoiceAlreadyExists},
+	{"channel not found", ErrChannelNotFound},
+	{"unable to find channel", ErrChannelNotFound},
+	{"edge not found", ErrChannelNotFound},
+	{"insufficient funds", ErrInsufficientBalance},
+	{"not enough witness outputs", ErrInsufficientBalance},
+	{"verification failed", ErrPermissionDenied},
+	{"permission denied", ErrPermissionDenied},
+	{"expected 1 macaroon", ErrPermissionDenied},
+	{"payment is in transition", ErrPaymentInFlight},
+}
+
+// RPCError is an error returned by lnd that was mapped to one of the typed
+// errors of this package. It can be matched with errors.Is against the typed
+// error, while its message and gRPC status stay unchanged so existing
+// checks on those keep working.
+type RPCError struct {
+	// Kind is the typed error that the lnd error was mapped to.
+	Kind error
+
+	err error
+}
+
+// Error returns the original error message of lnd.
+//
+// NOTE: This is part of the error interface.
+func (e *RPCError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the typed error, which allows it to be matched with
+// errors.Is.
+func (e *RPCError) Unwrap() error {
+	return e.Kind
+}
+
+// GRPCStatus returns the gRPC status of the original error, so that
+// status.Code and status.FromError still work on mapped errors.
+func (e *RPCError) GRPCStatus() *status.Status {
+	return status.Convert(e.err)
+}
+
+// mapRPCError maps a gRPC error that was returned by lnd to an RPCError if it
+// matches one of the typed errors. All other errors, including io.EOF at the
+// end of a stream, are returned unchanged.
+func mapRPCError(err error) error {
+	s, ok := status.FromError(err)
+	if err == nil || !ok {
+		return err
+	}
+
+	switch s.Code() {
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return &RPCError{Kind: ErrPermissionDenied, err: err}
+	}
+
+	for _, pattern := range rpcErrorPatterns {
+		if strings.Contains(s.Message(), pattern.substr) {
+			return &RPCError{Kind: pattern.kind, err: err}
+		}
+	}
+
+	return err
+}
+
+// errorMappingUnaryInterceptor maps the errors of unary calls to typed
+// errors.
+func errorMappingUnaryInterceptor(ctx context.Context, method string,
+	req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+	return mapRPCError(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// errorMappingStreamInterceptor maps the errors of streaming calls, including
+// those that are returned while receiving from or sending to the stream, to
+// typed errors.
+func errorMappingStreamInterceptor(ctx context.Context,
+	desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream,
+	error) {
+
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, mapRPCError(err)
+	}
+
+	return &errorMappingStream{ClientStream: stream}, nil
+}
+
+// errorMappingStream is a client stream that maps the errors of its sends and
+// receives to typed errors.
+type errorMappingStream struct {
+	grpc.ClientStream
+}
+
+// SendMsg sends a message on the stream.
+func (s *errorMappingStream) SendMsg(m interface{}) error {
+	return mapRPCError(s.ClientStream.SendMsg(m))
+}
+
+// RecvMsg receives a message from the stream.
+func (s *errorMappingStream) RecvMsg(m interface{}) error {
+	return mapRPCError(s.ClientStream.RecvMsg(m))
+}
@@ -0,0 +1,75 @@
+package lndclient
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestMapRPCError makes sure lnd errors are mapped to the expected typed
+// errors and that the gRPC status of mapped errors is preserved.
+func TestMapRPCError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected error
+	}{{
+		name: "invoice not found",
+		err: status.Error(
+			codes.Unknown, "unable to locate invoice",
+		),
+		expected: ErrInvoiceNotFound,
+	}, {
+		name: "insufficient funds",
+		err: status.Error(codes.Unknown, "insufficient funds "+
+			"available to construct transaction"),
+		expected: ErrInsufficientBalance,
+	}, {
+		name:     "permission denied code",
+		err:      status.Error(codes.PermissionDenied, "denied"),
+		expected: ErrPermissionDenied,
+	}, {
+		name:     "unknown error",
+		err:      status.Error(codes.Unknown, "something else"),
+		expected: nil,
+	}, {
+		name:     "end of stream",
+		err:      io.EOF,
+		expected: nil,
+	}}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			mapped := mapRPCError(testCase.err)
+
+			if mapped.Error() != testCase.err.Error() {
+				t.Fatalf("expected message %v, got %v",
+					testCase.err, mapped)
+			}
+
+			if testCase.expected == nil {
+				if mapped != testCase.err {
+					t.Fatalf("expected unchanged error, "+
+						"got %v", mapped)
+				}
+				return
+			}
+
+			if !errors.Is(mapped, testCase.expected) {
+				t.Fatalf("expected %v, got %v",
+					testCase.expected, mapped)
+			}
+
+			if status.Code(mapped) != status.Code(testCase.err) {
+				t.Fatalf("expected code %v, got %v",
+					status.Code(testCase.err),
+					status.Code(mapped))
+			}
+		})
+	}
+}
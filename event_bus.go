@@ -0,0 +1,217 @@
+package lndclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// EventCursor is a position in the event stream of an EventBus. Persisting
+// the cursor of the last processed event allows resuming the stream after a
+// restart without missing events.
+type EventCursor struct {
+	// InvoiceAddIndex is the add index of the last added invoice that was
+	// delivered.
+	InvoiceAddIndex uint64
+
+	// InvoiceSettleIndex is the settle index of the last settled invoice
+	// that was delivered.
+	InvoiceSettleIndex uint64
+
+	// PaymentIndex is the sequence number up to which all payments have
+	// reached a final state and were delivered.
+	PaymentIndex uint64
+}
+
+// Event is a single event of an EventBus. Exactly one of Invoice, Payment and
+// HtlcEvent is set.
+type Event struct {
+	// Invoice is set for an invoice that was added or settled.
+	Invoice *Invoice
+
+	// Payment is set for a payment that succeeded or failed.
+	Payment *Payment
+
+	// HtlcEvent is set for an event of an htlc that is sent, received or
+	// forwarded by lnd.
+	HtlcEvent *HtlcEvent
+
+	// Cursor is the position in the event stream after this event.
+	Cursor EventCursor
+}
+
+// EventBus multiplexes invoice updates, final payment results and htlc events
+// into a single ordered stream of events. Delivery of invoices and payments is
+// at-least-once: after resuming from a cursor, events that were delivered but
+// not yet covered by the cursor are delivered again. lnd doesn't keep htlc
+// events, so they are only delivered while the bus is subscribed and are
+// missed across restarts.
+type EventBus struct {
+	client       LightningClient
+	router       RouterClient
+	pollInterval time.Duration
+	cursor       EventCursor
+
+	// delivered holds the sequence numbers of payments after the cursor
+	// that were already delivered, because an earlier payment is still in
+	// flight.
+	delivered map[uint64]struct{}
+}
+
+// NewEventBus returns an event bus that starts after the given cursor. The
+// zero cursor delivers all invoices and payments from the beginning. As lnd
+// doesn't offer a subscription to payments, payments are polled in the given
+// interval. If the router is nil, no htlc events are delivered.
+func NewEventBus(client LightningClient, router RouterClient,
+	cursor EventCursor, paymentPollInterval time.Duration) *EventBus {
+
+	return &EventBus{
+		client:       client,
+		router:       router,
+		pollInterval: paymentPollInterval,
+		cursor:       cursor,
+		delivered:    make(map[uint64]struct{}),
+	}
+}
+
+// Subscribe starts the event stream. Events are delivered on the returned
+// event channel until the context is canceled or an error occurs, which is
+// delivered on the error channel. A bus can only be subscribed to once.
+func (b *EventBus) Subscribe(ctx context.Context) (<-chan Event, <-chan error,
+	error) {
+
+	invoiceChan, invoiceErrChan, err := b.client.SubscribeInvoices(
+		ctx, InvoiceSubscription{
+			AddIndex:    b.cursor.InvoiceAddIndex,
+			SettleIndex: b.cursor.InvoiceSettleIndex,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Without a router, the htlc channels stay nil and are never ready.
+	var (
+		htlcChan    <-chan *HtlcEvent
+		htlcErrChan <-chan error
+	)
+	if b.router != nil {
+		htlcChan, htlcErrChan, err = b.router.SubscribeHtlcEvents(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	eventChan := make(chan Event)
+	errChan := make(chan error, 1)
+
+	go func() {
+		// Poll payments right away, so that the payments after the
+		// cursor are delivered without waiting for the first tick.
+		poll := time.After(0)
+		for {
+			var err error
+			select {
+			case invoice, ok := <-invoiceChan:
+				if !ok {
+					return
+				}
+				err = b.deliverInvoice(ctx, eventChan, invoice)
+
+			case err = <-invoiceErrChan:
+
+			case htlcEvent := <-htlcChan:
+				event := Event{HtlcEvent: htlcEvent}
+				err = b.deliver(ctx, eventChan, event)
+
+			case err = <-htlcErrChan:
+
+			case <-poll:
+				err = b.pollPayments(ctx, eventChan)
+				poll = time.After(b.pollInterval)
+
+			case <-ctx.Done():
+				return
+			}
+
+			if err == nil {
+				continue
+			}
+
+			// Errors caused by the caller canceling the context
+			// aren't reported.
+			if ctx.Err() == nil {
+				errChan <- err
+			}
+			return
+		}
+	}()
+
+	return eventChan, errChan, nil
+}
+
+// deliverInvoice advances the invoice indices of the cursor and delivers the
+// invoice.
+func (b *EventBus) deliverInvoice(ctx context.Context, eventChan chan Event,
+	invoice *Invoice) error {
+
+	if invoice.AddIndex > b.cursor.InvoiceAddIndex {
+		b.cursor.InvoiceAddIndex = invoice.AddIndex
+	}
+	if invoice.SettleIndex > b.cursor.InvoiceSettleIndex {
+		b.cursor.InvoiceSettleIndex = invoice.SettleIndex
+	}
+
+	return b.deliver(ctx, eventChan, Event{Invoice: invoice})
+}
+
+// pollPayments delivers all payments after the cursor that reached a final
+// state and weren't delivered yet. The cursor only advances up to the first
+// payment that is still in flight.
+func (b *EventBus) pollPayments(ctx context.Context,
+	eventChan chan Event) error {
+
+	inFlight := false
+	return ForEachPayment(ctx, b.client, ListPaymentsRequest{
+		Offset:            b.cursor.PaymentIndex,
+		IncludeIncomplete: true,
+	}, func(payment Payment) error {
+		switch payment.Status.State {
+		case lnrpc.Payment_SUCCEEDED, lnrpc.Payment_FAILED:
+
+		default:
+			inFlight = true
+			return nil
+		}
+
+		_, ok := b.delivered[payment.SequenceNumber]
+		if !inFlight {
+			b.cursor.PaymentIndex = payment.SequenceNumber
+			delete(b.delivered, payment.SequenceNumber)
+		} else {
+			b.delivered[payment.SequenceNumber] = struct{}{}
+		}
+
+		if ok {
+			return nil
+		}
+
+		return b.deliver(ctx, eventChan, Event{Payment: &payment})
+	})
+}
+
+// deliver sends an event with the current cursor to the caller.
+func (b *EventBus) deliver(ctx context.Context, eventChan chan Event,
+	event Event) error {
+
+	event.Cursor = b.cursor
+
+	select {
+	case eventChan <- event:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,172 @@
+package lndclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// eventBusClient is a lightning client that serves a fixed set of payments
+// and invoice updates.
+type eventBusClient struct {
+	LightningClient
+
+	payments []Payment
+	invoices chan *Invoice
+}
+
+func (c *eventBusClient) ListPayments(_ context.Context,
+	req ListPaymentsRequest) (*ListPaymentsResponse, error) {
+
+	resp := &ListPaymentsResponse{}
+	for _, payment := range c.payments {
+		if payment.SequenceNumber <= req.Offset {
+			continue
+		}
+
+		if len(resp.Payments) == 0 {
+			resp.FirstIndexOffset = payment.SequenceNumber
+		}
+		resp.LastIndexOffset = payment.SequenceNumber
+		resp.Payments = append(resp.Payments, payment)
+	}
+
+	return resp, nil
+}
+
+func (c *eventBusClient) SubscribeInvoices(_ context.Context,
+	_ InvoiceSubscription) (<-chan *Invoice, <-chan error, error) {
+
+	return c.invoices, make(chan error), nil
+}
+
+// eventBusRouter is a router client that serves htlc events.
+type eventBusRouter struct {
+	RouterClient
+
+	events chan *HtlcEvent
+}
+
+func (r *eventBusRouter) SubscribeHtlcEvents(_ context.Context) (
+	<-chan *HtlcEvent, <-chan error, error) {
+
+	return r.events, make(chan error), nil
+}
+
+// testPayment returns a payment with the given sequence number and state.
+func testPayment(seq uint64, state lnrpc.Payment_PaymentStatus) Payment {
+	return Payment{
+		SequenceNumber: seq,
+		Status: &PaymentStatus{
+			State: state,
+		},
+	}
+}
+
+// pollEvents polls the payments of the bus and returns the delivered events.
+func pollEvents(t *testing.T, bus *EventBus) []Event {
+	eventChan := make(chan Event, 10)
+	err := bus.pollPayments(context.Background(), eventChan)
+	if err != nil {
+		t.Fatalf("unable to poll payments: %v", err)
+	}
+	close(eventChan)
+
+	var events []Event
+	for event := range eventChan {
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// assertPayments asserts that the events are the payments with the given
+// sequence numbers.
+func assertPayments(t *testing.T, events []Event, seqs ...uint64) {
+	if len(events) != len(seqs) {
+		t.Fatalf("expected %v events, got %v", len(seqs), len(events))
+	}
+
+	for i, event := range events {
+		if event.Payment.SequenceNumber != seqs[i] {
+			t.Fatalf("expected payment %v, got %v", seqs[i],
+				event.Payment.SequenceNumber)
+		}
+	}
+}
+
+// TestEventBusPayments tests that payments in flight hold back the cursor and
+// that payments after it are delivered again when resuming.
+func TestEventBusPayments(t *testing.T) {
+	client := &eventBusClient{
+		payments: []Payment{
+			testPayment(1, lnrpc.Payment_SUCCEEDED),
+			testPayment(2, lnrpc.Payment_IN_FLIGHT),
+			testPayment(3, lnrpc.Payment_FAILED),
+		},
+	}
+	bus := NewEventBus(client, nil, EventCursor{}, time.Minute)
+
+	// The final payments are delivered, but the cursor stops before the
+	// payment that is still in flight.
+	events := pollEvents(t, bus)
+	assertPayments(t, events, 1, 3)
+	if events[1].Cursor.PaymentIndex != 1 {
+		t.Fatalf("expected cursor 1, got %v",
+			events[1].Cursor.PaymentIndex)
+	}
+
+	// Payments are only delivered once by the same bus.
+	assertPayments(t, pollEvents(t, bus))
+
+	// A bus that resumes from the cursor delivers the final payment after
+	// the cursor again.
+	resumed := NewEventBus(client, nil, events[1].Cursor, time.Minute)
+	assertPayments(t, pollEvents(t, resumed), 3)
+
+	// Once the payment in flight succeeds, it is delivered and the cursor
+	// advances past all payments.
+	client.payments[1] = testPayment(2, lnrpc.Payment_SUCCEEDED)
+	events = pollEvents(t, bus)
+	assertPayments(t, events, 2)
+	if bus.cursor.PaymentIndex != 3 {
+		t.Fatalf("expected cursor 3, got %v", bus.cursor.PaymentIndex)
+	}
+	if len(bus.delivered) != 0 {
+		t.Fatalf("expected no delivered payments after the cursor")
+	}
+}
+
+// TestEventBusSubscribe tests that invoices and htlc events are delivered on
+// the event stream.
+func TestEventBusSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &eventBusClient{
+		invoices: make(chan *Invoice),
+	}
+	router := &eventBusRouter{
+		events: make(chan *HtlcEvent),
+	}
+	bus := NewEventBus(client, router, EventCursor{}, time.Minute)
+
+	eventChan, _, err := bus.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+
+	client.invoices <- &Invoice{AddIndex: 5}
+	event := <-eventChan
+	if event.Invoice == nil || event.Cursor.InvoiceAddIndex != 5 {
+		t.Fatalf("expected invoice event, got %+v", event)
+	}
+
+	router.events <- &HtlcEvent{IncomingChannelID: 7}
+	event = <-eventChan
+	if event.HtlcEvent == nil || event.HtlcEvent.IncomingChannelID != 7 {
+		t.Fatalf("expected htlc event, got %+v", event)
+	}
+}
@@ -0,0 +1,162 @@
+package lndclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnrpc/verrpc"
+)
+
+// ErrUnsupportedByLnd is the error that is returned if a feature is used that
+// the connected lnd node does not support, either because its version is too
+// old or because a required build tag is not enabled.
+var ErrUnsupportedByLnd = errors.New("feature not supported by connected " +
+	"lnd node")
+
+// Feature describes a piece of lnd functionality whose availability depends
+// on the version and build tags of the connected lnd node.
+type Feature struct {
+	// Name is a human readable name of the feature.
+	Name string
+
+	// MinVersion is the minimum lnd version and the build tags that are
+	// required for the feature.
+	MinVersion *verrpc.Version
+}
+
+// String returns the name of the feature.
+func (f Feature) String() string {
+	return f.Name
+}
+
+var (
+	// FeaturePsbtFunding is the ability to fund channels from an external
+	// wallet with a PSBT.
+	FeaturePsbtFunding = Feature{
+		Name: "PSBT channel funding",
+		MinVersion: &verrpc.Version{
+			AppMajor: 0,
+			AppMinor: 10,
+			AppPatch: 0,
+		},
+	}
+
+	// FeatureHtlcInterceptor is the ability to intercept forwarded htlcs.
+	FeatureHtlcInterceptor = Feature{
+		Name: "htlc interceptor",
+		MinVersion: &verrpc.Version{
+			AppMajor: 0,
+			AppMinor: 11,
+			AppPatch: 0,
+		},
+	}
+
+	// FeatureWatchtowerClient is the ability to manage the watchtowers
+	// that lnd backs up its channel states to.
+	FeatureWatchtowerClient = Feature{
+		Name: "watchtower client",
+		MinVersion: &verrpc.Version{
+			AppMajor:  0,
+			AppMinor:  11,
+			AppPatch:  0,
+			BuildTags: []string{"wtclientrpc"},
+		},
+	}
+
+	// FeatureWatchtowerServer is the ability to query the watchtower that
+	// is run by lnd.
+	FeatureWatchtowerServer = Feature{
+		Name: "watchtower server",
+		MinVersion: &verrpc.Version{
+			AppMajor:  0,
+			AppMinor:  11,
+			AppPatch:  0,
+			BuildTags: []string{"watchtowerrpc"},
+		},
+	}
+
+	// FeatureImportMissionControl is the ability to import mission control
+	// pair data into lnd.
+	FeatureImportMissionControl = Feature{
+		Name: "mission control import",
+		MinVersion: &verrpc.Version{
+			AppMajor: 0,
+			AppMinor: 12,
+			AppPatch: 0,
+		},
+	}
+
+	// FeatureAMP is the ability to send and receive atomic multi-path
+	// payments.
+	FeatureAMP = Feature{
+		Name: "AMP payments",
+		MinVersion: &verrpc.Version{
+			AppMajor: 0,
+			AppMinor: 13,
+			AppPatch: 0,
+		},
+	}
+
+	// FeatureWalletAccounts is the ability to manage multiple on-chain
+	// wallet accounts, including watch-only accounts.
+	FeatureWalletAccounts = Feature{
+		Name: "wallet accounts",
+		MinVersion: &verrpc.Version{
+			AppMajor:  0,
+			AppMinor:  13,
+			AppPatch:  0,
+			BuildTags: []string{"walletrpc"},
+		},
+	}
+
+	// FeatureTaprootChannels is the ability to open simple taproot
+	// channels.
+	FeatureTaprootChannels = Feature{
+		Name: "taproot channels",
+		MinVersion: &verrpc.Version{
+			AppMajor: 0,
+			AppMinor: 17,
+			AppPatch: 0,
+		},
+	}
+)
+
+// Supports returns true if the connected lnd node supports the given feature.
+// If the version of the connected node is unknown, no feature is reported as
+// supported.
+func (s *LndServices) Supports(feature Feature) bool {
+	return s.AssertSupported(feature) == nil
+}
+
+// AssertSupported returns an error wrapping ErrUnsupportedByLnd if the
+// connected lnd node does not support the given feature. This allows callers
+// to fail early with a descriptive error instead of getting an unimplemented
+// error from lnd.
+func (s *LndServices) AssertSupported(feature Feature) error {
+	return assertFeatureSupported(s.Version, feature)
+}
+
+// assertFeatureSupported checks the given lnd version against the
+// requirements of a feature.
+func assertFeatureSupported(version *verrpc.Version, feature Feature) error {
+	if version == nil {
+		return fmt.Errorf("%w: %v requires lnd %v, version unknown",
+			ErrUnsupportedByLnd, feature,
+			VersionString(feature.MinVersion))
+	}
+
+	err := assertVersionCompatible(version, feature.MinVersion)
+	if err == nil {
+		err = assertBuildTagsEnabled(
+			version, feature.MinVersion.BuildTags,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v requires lnd %v, connected to %v",
+			ErrUnsupportedByLnd, feature,
+			VersionString(feature.MinVersion),
+			VersionString(version))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package lndclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc/verrpc"
+)
+
+// TestAssertFeatureSupported makes sure features are only reported as
+// supported if both the version and the build tags of the connected lnd node
+// satisfy the requirements of the feature.
+func TestAssertFeatureSupported(t *testing.T) {
+	feature := Feature{
+		Name: "test feature",
+		MinVersion: &verrpc.Version{
+			AppMajor:  0,
+			AppMinor:  11,
+			AppPatch:  0,
+			BuildTags: []string{"walletrpc"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		version   *verrpc.Version
+		supported bool
+	}{{
+		name:      "unknown version",
+		version:   nil,
+		supported: false,
+	}, {
+		name: "version too old",
+		version: &verrpc.Version{
+			AppMajor:  0,
+			AppMinor:  10,
+			AppPatch:  4,
+			BuildTags: []string{"walletrpc"},
+		},
+		supported: false,
+	}, {
+		name: "build tag missing",
+		version: &verrpc.Version{
+			AppMajor:  0,
+			AppMinor:  12,
+			AppPatch:  0,
+			BuildTags: []string{"signrpc"},
+		},
+		supported: false,
+	}, {
+		name: "supported",
+		version: &verrpc.Version{
+			AppMajor:  0,
+			AppMinor:  11,
+			AppPatch:  1,
+			BuildTags: []string{"signrpc", "walletrpc"},
+		},
+		supported: true,
+	}}
+
+	for _, tc := range testCases {
+		err := assertFeatureSupported(tc.version, feature)
+		if tc.supported && err != nil {
+			t.Fatalf("%v: unexpected error: %v", tc.name, err)
+		}
+		if !tc.supported && !errors.Is(err, ErrUnsupportedByLnd) {
+			t.Fatalf("%v: unexpected error. got '%v' wanted '%v'",
+				tc.name, err, ErrUnsupportedByLnd)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package lndclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// ForwardingStats holds aggregated statistics over a set of forwarding
+// events.
+type ForwardingStats struct {
+	// Count is the number of forwards.
+	Count int
+
+	// VolumeIn is the total amount that was forwarded into our node.
+	VolumeIn lnwire.MilliSatoshi
+
+	// VolumeOut is the total amount that was forwarded out of our node.
+	VolumeOut lnwire.MilliSatoshi
+
+	// Fees is the total amount of fees earned.
+	Fees lnwire.MilliSatoshi
+}
+
+// add adds a forwarding event to the statistics.
+func (f *ForwardingStats) add(event ForwardingEvent) {
+	f.Count++
+	f.VolumeIn += event.AmountMsatIn
+	f.VolumeOut += event.AmountMsatOut
+	f.Fees += event.FeeMsat
+}
+
+// ForwardingReport holds the forwarding statistics of a period, both in total
+// and broken down by channel.
+type ForwardingReport struct {
+	// Total holds the statistics of all forwards.
+	Total ForwardingStats
+
+	// Incoming holds the statistics of the forwards that arrived on each
+	// channel, keyed by channel ID.
+	Incoming map[uint64]*ForwardingStats
+
+	// Outgoing holds the statistics of the forwards that left through
+	// each channel, keyed by channel ID. Fees are attributed to the
+	// outgoing channel, as it's the channel whose policy they were paid
+	// for.
+	Outgoing map[uint64]*ForwardingStats
+}
+
+// AggregateForwards aggregates the given forwarding events into a report.
+func AggregateForwards(events []ForwardingEvent) *ForwardingReport {
+	report := &ForwardingReport{
+		Incoming: make(map[uint64]*ForwardingStats),
+		Outgoing: make(map[uint64]*ForwardingStats),
+	}
+
+	for _, event := range events {
+		report.Total.add(event)
+
+		in, ok := report.Incoming[event.ChannelIn]
+		if !ok {
+			in = &ForwardingStats{}
+			report.Incoming[event.ChannelIn] = in
+		}
+		in.add(event)
+
+		out, ok := report.Outgoing[event.ChannelOut]
+		if !ok {
+			out = &ForwardingStats{}
+			report.Outgoing[event.ChannelOut] = out
+		}
+		out.add(event)
+	}
+
+	return report
+}
+
+// ByPeer aggregates the per channel statistics of the report by the peer of
+// each channel, using the given mapping from channel ID to peer. Channels
+// without a known peer are left out.
+func (r *ForwardingReport) ByPeer(peers map[uint64]route.Vertex) (
+	incoming, outgoing map[route.Vertex]*ForwardingStats) {
+
+	return aggregateByPeer(r.Incoming, peers),
+		aggregateByPeer(r.Outgoing, peers)
+}
+
+// aggregateByPeer sums up per channel statistics by the peer of the channel.
+func aggregateByPeer(channels map[uint64]*ForwardingStats,
+	peers map[uint64]route.Vertex) map[route.Vertex]*ForwardingStats {
+
+	byPeer := make(map[route.Vertex]*ForwardingStats)
+	for chanID, stats := range channels {
+		peer, ok := peers[chanID]
+		if !ok {
+			continue
+		}
+
+		total, ok := byPeer[peer]
+		if !ok {
+			total = &ForwardingStats{}
+			byPeer[peer] = total
+		}
+
+		total.Count += stats.Count
+		total.VolumeIn += stats.VolumeIn
+		total.VolumeOut += stats.VolumeOut
+		total.Fees += stats.Fees
+	}
+
+	return byPeer
+}
+
+// ChannelPeers returns a mapping from channel ID to peer for all open and
+// closed channels of our node, for use with ForwardingReport.ByPeer.
+func ChannelPeers(ctx context.Context,
+	client LightningClient) (map[uint64]route.Vertex, error) {
+
+	open, err := client.ListChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := client.ClosedChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make(map[uint64]route.Vertex, len(open)+len(closed))
+	for _, channel := range open {
+		peers[channel.ChannelID] = channel.PubKeyBytes
+	}
+	for _, channel := range closed {
+		peers[channel.ChannelID] = channel.PubKeyBytes
+	}
+
+	return peers, nil
+}
+
+// ForwardingTracker keeps an in-memory copy of our forwarding history that
+// it continuously extends by paging through ForwardingHistory, and provides
+// reports over arbitrary periods of it.
+type ForwardingTracker struct {
+	client       LightningClient
+	pollInterval time.Duration
+
+	// mu protects the fields below.
+	mu sync.Mutex
+
+	// offset is the index offset of the next event to query.
+	offset uint32
+
+	events []ForwardingEvent
+}
+
+// NewForwardingTracker returns a tracker that polls for new forwarding events
+// in the given interval once it is running.
+func NewForwardingTracker(client LightningClient,
+	pollInterval time.Duration) *ForwardingTracker {
+
+	return &ForwardingTracker{
+		client:       client,
+		pollInterval: pollInterval,
+	}
+}
+
+// Run syncs the forwarding history in regular intervals until the context is
+// canceled or a sync fails.
+func (f *ForwardingTracker) Run(ctx context.Context) error {
+	for {
+		if err := f.Sync(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-time.After(f.pollInterval):
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Sync queries all forwarding events that happened since the last sync. It
+// must not be called concurrently with itself or Run.
+func (f *ForwardingTracker) Sync(ctx context.Context) error {
+	f.mu.Lock()
+	offset := f.offset
+	f.mu.Unlock()
+
+	// We always query the full history and skip the events we already
+	// know, so that the offset stays valid between syncs.
+	var events []ForwardingEvent
+	err := ForEachForwardingEvent(ctx, f.client, ForwardingHistoryRequest{
+		StartTime: time.Unix(0, 0),
+		EndTime:   time.Now(),
+		Offset:    offset,
+	}, func(event ForwardingEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.events = append(f.events, events...)
+	f.offset = offset + uint32(len(events))
+
+	return nil
+}
+
+// Report returns a report over the forwarding events of the period between
+// start and end that are known to the tracker.
+func (f *ForwardingTracker) Report(start, end time.Time) *ForwardingReport {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var events []ForwardingEvent
+	for _, event := range f.events {
+		if event.Timestamp.Before(start) || event.Timestamp.After(end) {
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return AggregateForwards(events)
+}
+
+// Window returns a report over the forwarding events of the given duration
+// up to now.
+func (f *ForwardingTracker) Window(window time.Duration) *ForwardingReport {
+	now := time.Now()
+	return f.Report(now.Add(-window), now)
+}
@@ -0,0 +1,60 @@
+package lndclient
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// TestAggregateForwards tests the aggregation of forwarding events by channel
+// and by peer.
+func TestAggregateForwards(t *testing.T) {
+	events := []ForwardingEvent{{
+		ChannelIn:     1,
+		ChannelOut:    2,
+		AmountMsatIn:  1010,
+		AmountMsatOut: 1000,
+		FeeMsat:       10,
+	}, {
+		ChannelIn:     3,
+		ChannelOut:    2,
+		AmountMsatIn:  2020,
+		AmountMsatOut: 2000,
+		FeeMsat:       20,
+	}, {
+		ChannelIn:     2,
+		ChannelOut:    1,
+		AmountMsatIn:  505,
+		AmountMsatOut: 500,
+		FeeMsat:       5,
+	}}
+
+	report := AggregateForwards(events)
+
+	if report.Total.Count != 3 || report.Total.Fees != 35 {
+		t.Fatalf("unexpected total: %+v", report.Total)
+	}
+
+	out := report.Outgoing[2]
+	if out.Count != 2 || out.VolumeOut != 3000 || out.Fees != 30 {
+		t.Fatalf("unexpected outgoing stats: %+v", out)
+	}
+
+	// Channels 1 and 3 are with the same peer, channel 2 has no known
+	// peer.
+	peer := route.Vertex{1}
+	incoming, outgoing := report.ByPeer(map[uint64]route.Vertex{
+		1: peer,
+		3: peer,
+	})
+
+	if len(incoming) != 1 || incoming[peer].Count != 2 ||
+		incoming[peer].VolumeIn != 3030 {
+
+		t.Fatalf("unexpected incoming peer stats: %+v", incoming[peer])
+	}
+
+	if len(outgoing) != 1 || outgoing[peer].Fees != 5 {
+		t.Fatalf("unexpected outgoing peer stats: %+v", outgoing[peer])
+	}
+}
@@ -0,0 +1,285 @@
+package lndclient
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// GraphCache is an in-memory copy of lnd's channel graph. It is loaded once
+// with DescribeGraph and then kept up to date with the updates of
+// SubscribeGraph, which makes lookups cheap enough for interactive use. The
+// values returned by the lookups share memory with the cache and must not be
+// modified.
+type GraphCache struct {
+	client          LightningClient
+	refreshInterval time.Duration
+
+	// loaded is closed once the graph was loaded for the first time.
+	loaded     chan struct{}
+	loadedOnce sync.Once
+
+	// mu protects the maps below.
+	mu sync.RWMutex
+
+	nodes     map[route.Vertex]*Node
+	edges     map[uint64]*ChannelEdge
+	nodeChans map[route.Vertex]map[uint64]struct{}
+}
+
+// NewGraphCache returns a graph cache that is filled once it is running. If a
+// refresh interval is set, the full graph is reloaded in that interval to
+// correct any drift from missed updates.
+func NewGraphCache(client LightningClient,
+	refreshInterval time.Duration) *GraphCache {
+
+	return &GraphCache{
+		client:          client,
+		refreshInterval: refreshInterval,
+		loaded:          make(chan struct{}),
+		nodes:           make(map[route.Vertex]*Node),
+		edges:           make(map[uint64]*ChannelEdge),
+		nodeChans:       make(map[route.Vertex]map[uint64]struct{}),
+	}
+}
+
+// Run loads the graph and applies graph updates until the context is
+// canceled or an error occurs.
+func (g *GraphCache) Run(ctx context.Context) error {
+	// We subscribe before loading the graph, so we don't miss updates that
+	// happen while the graph is loaded. Updates that are older than the
+	// loaded graph are detected by their timestamp.
+	updates, errChan, err := g.client.SubscribeGraph(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := g.load(ctx); err != nil {
+		return err
+	}
+
+	var refresh <-chan time.Time
+	if g.refreshInterval > 0 {
+		ticker := time.NewTicker(g.refreshInterval)
+		defer ticker.Stop()
+
+		refresh = ticker.C
+	}
+
+	for {
+		select {
+		case update := <-updates:
+			g.applyUpdate(update)
+
+		case <-refresh:
+			if err := g.load(ctx); err != nil {
+				return err
+			}
+
+		case err := <-errChan:
+			return err
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitForLoad blocks until the graph was loaded for the first time or the
+// context is canceled.
+func (g *GraphCache) WaitForLoad(ctx context.Context) error {
+	select {
+	case <-g.loaded:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// load replaces the cached graph with the current graph of lnd.
+func (g *GraphCache) load(ctx context.Context) error {
+	graph, err := g.client.DescribeGraph(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	nodes := make(map[route.Vertex]*Node, len(graph.Nodes))
+	for i := range graph.Nodes {
+		node := graph.Nodes[i]
+		nodes[node.PubKey] = &node
+	}
+
+	edges := make(map[uint64]*ChannelEdge, len(graph.Edges))
+	nodeChans := make(map[route.Vertex]map[uint64]struct{})
+	for i := range graph.Edges {
+		edge := graph.Edges[i]
+		edges[edge.ChannelID] = &edge
+		addNodeChan(nodeChans, edge.Node1, edge.ChannelID)
+		addNodeChan(nodeChans, edge.Node2, edge.ChannelID)
+	}
+
+	g.mu.Lock()
+	g.nodes = nodes
+	g.edges = edges
+	g.nodeChans = nodeChans
+	g.mu.Unlock()
+
+	g.loadedOnce.Do(func() {
+		close(g.loaded)
+	})
+
+	return nil
+}
+
+// applyUpdate applies a graph update to the cached graph.
+func (g *GraphCache) applyUpdate(update *GraphTopologyUpdate) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, nodeUpdate := range update.NodeUpdates {
+		node, ok := g.nodes[nodeUpdate.PubKey]
+		if !ok {
+			node = &Node{PubKey: nodeUpdate.PubKey}
+			g.nodes[nodeUpdate.PubKey] = node
+		}
+
+		node.Alias = nodeUpdate.Alias
+		node.Color = nodeUpdate.Color
+		node.Addresses = nodeUpdate.Addresses
+		node.Features = nodeUpdate.Features
+	}
+
+	for _, edgeUpdate := range update.ChannelEdgeUpdates {
+		g.applyEdgeUpdate(edgeUpdate)
+	}
+
+	for _, closeUpdate := range update.ChannelCloseUpdates {
+		edge, ok := g.edges[closeUpdate.ChannelID]
+		if !ok {
+			continue
+		}
+
+		delete(g.edges, closeUpdate.ChannelID)
+		delete(g.nodeChans[edge.Node1], closeUpdate.ChannelID)
+		delete(g.nodeChans[edge.Node2], closeUpdate.ChannelID)
+	}
+}
+
+// applyEdgeUpdate applies a channel policy update to the cached graph, adding
+// the channel if it isn't known yet. The caller must hold the write lock.
+func (g *GraphCache) applyEdgeUpdate(update ChannelEdgeUpdate) {
+	edge, ok := g.edges[update.ChannelID]
+	if !ok {
+		// Like lnd, we order the nodes of a channel by their pubkey.
+		node1, node2 := update.AdvertisingNode, update.ConnectingNode
+		if bytes.Compare(node1[:], node2[:]) > 0 {
+			node1, node2 = node2, node1
+		}
+
+		edge = &ChannelEdge{
+			ChannelID:    update.ChannelID,
+			ChannelPoint: update.ChannelPoint.String(),
+			Capacity:     update.Capacity,
+			Node1:        node1,
+			Node2:        node2,
+		}
+		g.edges[update.ChannelID] = edge
+		addNodeChan(g.nodeChans, node1, update.ChannelID)
+		addNodeChan(g.nodeChans, node2, update.ChannelID)
+	}
+
+	policy := &edge.Node2Policy
+	if update.AdvertisingNode == edge.Node1 {
+		policy = &edge.Node1Policy
+	}
+
+	// We can't compare the update with the policy we already have, lnd
+	// doesn't set the update time of the policies in topology updates.
+	// Updates are streamed in order, so the latest one wins.
+	*policy = update.RoutingPolicy
+}
+
+// addNodeChan records that the node is part of the channel.
+func addNodeChan(nodeChans map[route.Vertex]map[uint64]struct{},
+	node route.Vertex, chanID uint64) {
+
+	chans, ok := nodeChans[node]
+	if !ok {
+		chans = make(map[uint64]struct{})
+		nodeChans[node] = chans
+	}
+
+	chans[chanID] = struct{}{}
+}
+
+// Node returns the node with the given pubkey, if it is known.
+func (g *GraphCache) Node(pubKey route.Vertex) (Node, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	node, ok := g.nodes[pubKey]
+	if !ok {
+		return Node{}, false
+	}
+
+	return *node, true
+}
+
+// Channel returns the channel with the given ID, if it is known.
+func (g *GraphCache) Channel(chanID uint64) (ChannelEdge, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	edge, ok := g.edges[chanID]
+	if !ok {
+		return ChannelEdge{}, false
+	}
+
+	return *edge, true
+}
+
+// NodeChannels returns all known channels of the node with the given pubkey.
+func (g *GraphCache) NodeChannels(pubKey route.Vertex) []ChannelEdge {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	chans := make([]ChannelEdge, 0, len(g.nodeChans[pubKey]))
+	for chanID := range g.nodeChans[pubKey] {
+		chans = append(chans, *g.edges[chanID])
+	}
+
+	return chans
+}
+
+// Policy returns the routing policy that the given node set for forwarding
+// over the channel with the given ID. Nil is returned if the channel or the
+// policy is not known.
+func (g *GraphCache) Policy(chanID uint64, node route.Vertex) *RoutingPolicy {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.policy(chanID, node)
+}
+
+// policy returns the policy of the given node for the channel. The caller
+// must hold the read lock.
+func (g *GraphCache) policy(chanID uint64, node route.Vertex) *RoutingPolicy {
+	edge, ok := g.edges[chanID]
+	switch {
+	case !ok:
+		return nil
+
+	case node == edge.Node1:
+		return edge.Node1Policy
+
+	case node == edge.Node2:
+		return edge.Node2Policy
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+package lndclient
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// TestGraphCacheUpdates tests that graph updates add channels, replace
+// policies and remove closed channels.
+func TestGraphCacheUpdates(t *testing.T) {
+	cache := NewGraphCache(nil, 0)
+
+	node1, node2 := route.Vertex{1}, route.Vertex{2}
+
+	// lnd doesn't set the update time of policies in topology updates, so
+	// they are unmarshalled as the unix epoch.
+	streamed := time.Unix(0, 0)
+
+	// An update for an unknown channel adds the channel, with the nodes
+	// ordered by pubkey. The policy has an update time, as it would have
+	// if it was loaded with DescribeGraph.
+	cache.applyUpdate(&GraphTopologyUpdate{
+		ChannelEdgeUpdates: []ChannelEdgeUpdate{{
+			ChannelID:       5,
+			AdvertisingNode: node2,
+			ConnectingNode:  node1,
+			RoutingPolicy: &RoutingPolicy{
+				FeeBaseMsat: 1000,
+				LastUpdate:  time.Unix(1000, 0),
+			},
+		}},
+	})
+
+	edge, ok := cache.Channel(5)
+	if !ok {
+		t.Fatalf("expected channel to be added")
+	}
+	if edge.Node1 != node1 || edge.Node2 != node2 {
+		t.Fatalf("unexpected node order: %v, %v", edge.Node1,
+			edge.Node2)
+	}
+	if len(cache.NodeChannels(node1)) != 1 {
+		t.Fatalf("expected channel for node 1")
+	}
+
+	// Streamed updates replace the policy, even though their update time
+	// isn't set.
+	for _, feeBase := range []lnwire.MilliSatoshi{2000, 3000} {
+		cache.applyUpdate(&GraphTopologyUpdate{
+			ChannelEdgeUpdates: []ChannelEdgeUpdate{{
+				ChannelID:       5,
+				AdvertisingNode: node2,
+				ConnectingNode:  node1,
+				RoutingPolicy: &RoutingPolicy{
+					FeeBaseMsat: feeBase,
+					LastUpdate:  streamed,
+				},
+			}},
+		})
+
+		policy := cache.Policy(5, node2)
+		if policy.FeeBaseMsat != feeBase {
+			t.Fatalf("expected base fee %v, got %v", feeBase,
+				policy.FeeBaseMsat)
+		}
+	}
+
+	if cache.Policy(5, node1) != nil {
+		t.Fatalf("expected no policy for node 1")
+	}
+
+	// Closing the channel removes it.
+	cache.applyUpdate(&GraphTopologyUpdate{
+		ChannelCloseUpdates: []ChannelCloseUpdate{{
+			ChannelID: 5,
+		}},
+	})
+
+	if _, ok := cache.Channel(5); ok {
+		t.Fatalf("expected channel to be removed")
+	}
+	if len(cache.NodeChannels(node1)) != 0 {
+		t.Fatalf("expected no channels for node 1")
+	}
+}
+
+// TestUnmarshalRawFeatures tests decoding the serialized feature vector of a
+// node update.
+func TestUnmarshalRawFeatures(t *testing.T) {
+	featureVector := lnwire.NewRawFeatureVector(
+		lnwire.DataLossProtectOptional, lnwire.TLVOnionPayloadOptional,
+	)
+
+	var b bytes.Buffer
+	if err := featureVector.Encode(&b); err != nil {
+		t.Fatalf("unable to encode features: %v", err)
+	}
+
+	features, err := unmarshalRawFeatures(b.Bytes())
+	if err != nil {
+		t.Fatalf("unable to decode features: %v", err)
+	}
+
+	expected := []lnwire.FeatureBit{
+		lnwire.DataLossProtectOptional, lnwire.TLVOnionPayloadOptional,
+	}
+	if len(features) != len(expected) {
+		t.Fatalf("expected features %v, got %v", expected, features)
+	}
+	for i, feature := range features {
+		if feature != expected[i] {
+			t.Fatalf("expected features %v, got %v", expected,
+				features)
+		}
+	}
+}
@@ -36,15 +36,23 @@ type invoicesClient struct {
 	client     invoicesrpc.InvoicesClient
 	invoiceMac serializedMacaroon
 	wg         sync.WaitGroup
+	quit       chan struct{}
 }
 
 func newInvoicesClient(conn *grpc.ClientConn, invoiceMac serializedMacaroon) *invoicesClient {
 	return &invoicesClient{
 		client:     invoicesrpc.NewInvoicesClient(conn),
 		invoiceMac: invoiceMac,
+		quit:       make(chan struct{}),
 	}
 }
 
+// stop signals all goroutines of the client to exit, even if they are
+// blocked delivering updates to a caller that stopped reading.
+func (s *invoicesClient) stop() {
+	close(s.quit)
+}
+
 func (s *invoicesClient) WaitForFinished() {
 	s.wg.Wait()
 }
@@ -118,6 +126,8 @@ func (s *invoicesClient) SubscribeSingleInvoice(ctx context.Context,
 			}:
 			case <-ctx.Done():
 				return
+			case <-s.quit:
+				return
 			}
 		}
 	}()
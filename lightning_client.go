@@ -7,18 +7,26 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcutil"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/record"
 	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"google.golang.org/grpc"
@@ -28,19 +36,46 @@ import (
 
 // LightningClient exposes base lightning functionality.
 type LightningClient interface {
+	// PayInvoice pays an invoice and delivers the final result on the
+	// returned channel. If the context is canceled or the client is
+	// stopped before the payment completed, a result with the
+	// cancellation error is delivered.
 	PayInvoice(ctx context.Context, invoice string,
 		maxFee btcutil.Amount,
 		outgoingChannel *uint64) chan PaymentResult
 
+	// PayInvoiceWithUpdates pays an invoice like PayInvoice, but also
+	// delivers intermediate updates on the progress of the payment, such
+	// as launched and failed htlc attempts. The update channel is closed
+	// before the final result is delivered and must be read until then.
+	PayInvoiceWithUpdates(ctx context.Context, invoice string,
+		maxFee btcutil.Amount, outgoingChannel *uint64) (
+		chan PaymentUpdate, chan PaymentResult)
+
 	GetInfo(ctx context.Context) (*Info, error)
 
 	EstimateFeeToP2WSH(ctx context.Context, amt btcutil.Amount,
 		confTarget int32) (btcutil.Amount, error)
 
+	// EstimateFee estimates the total fee and fee rate of a transaction
+	// that pays to the addresses and amounts provided.
+	EstimateFee(ctx context.Context, outputs map[string]btcutil.Amount,
+		confTarget int32) (btcutil.Amount, chainfee.SatPerKVByte, error)
+
 	ConfirmedWalletBalance(ctx context.Context) (btcutil.Amount, error)
 
-	AddInvoice(ctx context.Context, in *invoicesrpc.AddInvoiceData) (
-		lntypes.Hash, string, error)
+	// WalletBalance returns the confirmed and unconfirmed balance of our
+	// wallet.
+	WalletBalance(ctx context.Context) (*WalletBalance, error)
+
+	// ChannelBalance returns the sum of our local balances in open and
+	// pending channels.
+	ChannelBalance(ctx context.Context) (*ChannelBalance, error)
+
+	// AddInvoice adds an invoice to lnd. Optional invoice parameters can
+	// be set with the add invoice options.
+	AddInvoice(ctx context.Context, in *invoicesrpc.AddInvoiceData,
+		opts ...AddInvoiceOption) (lntypes.Hash, string, error)
 
 	// LookupInvoice looks up an invoice by hash.
 	LookupInvoice(ctx context.Context, hash lntypes.Hash) (*Invoice, error)
@@ -53,14 +88,18 @@ type LightningClient interface {
 	ListTransactions(ctx context.Context, startHeight,
 		endHeight int32) ([]Transaction, error)
 
-	// ListChannels retrieves all channels of the backing lnd node.
-	ListChannels(ctx context.Context) ([]ChannelInfo, error)
+	// ListChannels retrieves all channels of the backing lnd node. The
+	// channels returned can be filtered with list channels options.
+	ListChannels(ctx context.Context, opts ...ListChannelsOption) (
+		[]ChannelInfo, error)
 
 	// PendingChannels returns a list of lnd's pending channels.
 	PendingChannels(ctx context.Context) (*PendingChannels, error)
 
 	// ClosedChannels returns all closed channels of the backing lnd node.
-	ClosedChannels(ctx context.Context) ([]ClosedChannel, error)
+	// The channels returned can be filtered by close type.
+	ClosedChannels(ctx context.Context, closeTypes ...CloseType) (
+		[]ClosedChannel, error)
 
 	// ForwardingHistory makes a paginated call to our forwarding history
 	// endpoint.
@@ -84,21 +123,117 @@ type LightningClient interface {
 	// chanbackup.Multi payload.
 	ChannelBackups(ctx context.Context) ([]byte, error)
 
+	// SubscribeChannelBackups subscribes to updates of the channel
+	// backups. Every time a channel is opened or closed, the new
+	// encrypted chanbackup.Multi payload of all channels is delivered.
+	SubscribeChannelBackups(ctx context.Context) (<-chan []byte,
+		<-chan error, error)
+
+	// VerifyChannelBackups checks that the encrypted chanbackup.Multi
+	// payload provided can be decrypted and parsed by lnd.
+	VerifyChannelBackups(ctx context.Context, multiBackup []byte) error
+
 	// DecodePaymentRequest decodes a payment request.
 	DecodePaymentRequest(ctx context.Context,
 		payReq string) (*PaymentRequest, error)
 
 	// OpenChannel opens a channel to the peer provided with the amounts
-	// specified.
+	// specified. Optional channel parameters can be set with the open
+	// channel options.
 	OpenChannel(ctx context.Context, peer route.Vertex,
-		localSat, pushSat btcutil.Amount) (*wire.OutPoint, error)
+		localSat, pushSat btcutil.Amount, opts ...OpenChannelOption) (
+		*wire.OutPoint, error)
 
-	// CloseChannel closes the channel provided.
+	// CloseChannel closes the channel provided. Optional close parameters
+	// can be set with the close channel options.
 	CloseChannel(ctx context.Context, channel *wire.OutPoint,
-		force bool) (chan CloseChannelUpdate, chan error, error)
+		force bool, opts ...CloseChannelOption) (
+		chan CloseChannelUpdate, chan error, error)
+
+	// RegisterFundingShim registers a chan point shim with lnd, so that a
+	// channel that is opened to us by the peer with the shim's pending
+	// channel ID is funded by the shim's funding output.
+	RegisterFundingShim(ctx context.Context, shim ChanPointShim) error
+
+	// CancelFundingShim cancels the funding shim with the pending channel
+	// ID provided.
+	CancelFundingShim(ctx context.Context, pendingChanID [32]byte) error
 
 	// Connect attempts to connect to a peer at the host specified.
-	Connect(ctx context.Context, peer route.Vertex, host string) error
+	// Optional connection parameters can be set with the connect options.
+	// Being connected to the peer already is reported as a status rather
+	// than an error.
+	Connect(ctx context.Context, peer route.Vertex, host string,
+		opts ...ConnectOption) (ConnectStatus, error)
+
+	// SubscribeInvoices allows a client to subscribe to updates of newly
+	// added/settled invoices.
+	SubscribeInvoices(ctx context.Context, req InvoiceSubscription) (
+		<-chan *Invoice, <-chan error, error)
+
+	// SubscribeChannelEvents allows a client to subscribe to updates
+	// relevant to the state of channels. Events include new active
+	// channels, inactive channels, and closed channels.
+	SubscribeChannelEvents(ctx context.Context) (
+		<-chan *ChannelEventUpdate, <-chan error, error)
+
+	// SubscribeTransactions creates a uni-directional stream from the
+	// server to the client in which any newly discovered transactions
+	// relevant to the wallet are sent over.
+	SubscribeTransactions(ctx context.Context) (<-chan Transaction,
+		<-chan error, error)
+
+	// ListPeers gets a list the peers we are currently connected to.
+	ListPeers(ctx context.Context) ([]Peer, error)
+
+	// DescribeGraph returns our view of the graph.
+	DescribeGraph(ctx context.Context, includeUnannounced bool) (*Graph,
+		error)
+
+	// SubscribeGraph subscribes to updates of the channel graph, such as
+	// new or updated nodes and channels and closed channels.
+	SubscribeGraph(ctx context.Context) (<-chan *GraphTopologyUpdate,
+		<-chan error, error)
+
+	// GetChanInfo returns the channel info for the passed channel,
+	// including the routing policy for both end.
+	GetChanInfo(ctx context.Context, chanID uint64) (*ChannelEdge, error)
+
+	// GetNodeInfo returns node info for the pubkey provided.
+	GetNodeInfo(ctx context.Context, pubkey route.Vertex,
+		includeChannels bool) (*NodeInfo, error)
+
+	// QueryRoutes can query for routes to a given destination.
+	QueryRoutes(ctx context.Context, req QueryRoutesRequest) (
+		*QueryRoutesResponse, error)
+
+	// FeeReport returns the fee policies of all our channels and the fees
+	// we earned over the last day, week and month.
+	FeeReport(ctx context.Context) (*FeeReport, error)
+
+	// NewAddress generates a new address of the type provided.
+	NewAddress(ctx context.Context, addrType lnwallet.AddressType) (
+		btcutil.Address, error)
+
+	// SendCoins sends the amount provided to the address provided. It
+	// returns the txid of the transaction that was published.
+	SendCoins(ctx context.Context, req SendCoinsRequest) (string, error)
+
+	// RestoreChannelBackups restores all channels contained in the
+	// encrypted chanbackup.Multi payload provided.
+	RestoreChannelBackups(ctx context.Context, multiBackup []byte) error
+
+	// RestoreSingleChannelBackups restores the channels contained in the
+	// encrypted chanbackup.Single payloads provided.
+	RestoreSingleChannelBackups(ctx context.Context,
+		backups []SingleChannelBackup) error
+
+	// StopDaemon requests lnd to initiate a graceful shutdown.
+	StopDaemon(ctx context.Context) error
+
+	// GetRecoveryInfo returns information about the wallet recovery
+	// process.
+	GetRecoveryInfo(ctx context.Context) (*RecoveryInfo, error)
 }
 
 // Info contains info about the connected lnd node.
@@ -156,6 +291,11 @@ type ChannelInfo struct {
 	// Uptime is the total amount of time the peer has been observed as
 	// online over its lifetime.
 	Uptime time.Duration
+
+	// CloseAddr is the upfront shutdown address that our funds are paid
+	// out to in a cooperative close. It is empty if no address was set
+	// when the channel was opened.
+	CloseAddr string
 }
 
 // ClosedChannel represents a channel that has been closed.
@@ -193,6 +333,125 @@ type ClosedChannel struct {
 	// channel close. Note that this does not include cases where we need to
 	// sweep our commitment or htlcs.
 	SettledBalance btcutil.Amount
+
+	// Resolutions contains the on chain resolutions of the channel's
+	// outputs that required action from our node, for example sweeping our
+	// commitment output or htlcs after a force close.
+	Resolutions []Resolution
+}
+
+// ResolutionType describes the type of output that was resolved on chain.
+type ResolutionType uint8
+
+const (
+	// ResolutionTypeUnknown is set when the type of output is not known.
+	ResolutionTypeUnknown ResolutionType = iota
+
+	// ResolutionTypeAnchor represents the resolution of an anchor output.
+	ResolutionTypeAnchor
+
+	// ResolutionTypeIncomingHtlc represents the resolution of an incoming
+	// htlc.
+	ResolutionTypeIncomingHtlc
+
+	// ResolutionTypeOutgoingHtlc represents the resolution of an outgoing
+	// htlc.
+	ResolutionTypeOutgoingHtlc
+
+	// ResolutionTypeCommit represents the resolution of our time-locked
+	// commitment output.
+	ResolutionTypeCommit
+)
+
+// String returns the string representation of a resolution type.
+func (r ResolutionType) String() string {
+	switch r {
+	case ResolutionTypeAnchor:
+		return "Anchor"
+
+	case ResolutionTypeIncomingHtlc:
+		return "Incoming Htlc"
+
+	case ResolutionTypeOutgoingHtlc:
+		return "Outgoing Htlc"
+
+	case ResolutionTypeCommit:
+		return "Commit"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// ResolutionOutcome describes the outcome of an on chain resolution.
+type ResolutionOutcome uint8
+
+const (
+	// ResolutionOutcomeUnknown is set when the outcome is not known.
+	ResolutionOutcomeUnknown ResolutionOutcome = iota
+
+	// ResolutionOutcomeClaimed indicates that the output was claimed on
+	// chain.
+	ResolutionOutcomeClaimed
+
+	// ResolutionOutcomeUnclaimed indicates that the output was not claimed
+	// on chain.
+	ResolutionOutcomeUnclaimed
+
+	// ResolutionOutcomeAbandoned indicates that the resolver was abandoned
+	// and the output was not swept.
+	ResolutionOutcomeAbandoned
+
+	// ResolutionOutcomeFirstStage indicates that a two-stage htlc
+	// resolution has completed its first stage.
+	ResolutionOutcomeFirstStage
+
+	// ResolutionOutcomeTimeout indicates that an htlc was timed out on
+	// chain.
+	ResolutionOutcomeTimeout
+)
+
+// String returns the string representation of a resolution outcome.
+func (r ResolutionOutcome) String() string {
+	switch r {
+	case ResolutionOutcomeClaimed:
+		return "Claimed"
+
+	case ResolutionOutcomeUnclaimed:
+		return "Unclaimed"
+
+	case ResolutionOutcomeAbandoned:
+		return "Abandoned"
+
+	case ResolutionOutcomeFirstStage:
+		return "First Stage"
+
+	case ResolutionOutcomeTimeout:
+		return "Timeout"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// Resolution describes the on chain resolution of one of a closed channel's
+// outputs.
+type Resolution struct {
+	// Type is the type of output that was resolved.
+	Type ResolutionType
+
+	// Outcome is the outcome of the resolution.
+	Outcome ResolutionOutcome
+
+	// Outpoint is the outpoint that was resolved.
+	Outpoint wire.OutPoint
+
+	// Amount is the value of the output that was resolved.
+	Amount btcutil.Amount
+
+	// SweepTxid is the hash of the transaction that swept the output. This
+	// is empty if the output was not swept by our node.
+	SweepTxid string
 }
 
 // CloseType is an enum which represents the types of closes our channels may
@@ -348,12 +607,16 @@ var (
 	// still in flight.
 	PaymentResultInFlight = channeldb.ErrPaymentInFlight.Error()
 
-	paymentPollInterval = 3 * time.Second
+	// payInvoiceTimeout is the time after which lnd stops trying new
+	// routes for a payment started through PayInvoice.
+	payInvoiceTimeout = 60 * time.Second
 )
 
 type lightningClient struct {
 	client   lnrpc.LightningClient
+	router   routerrpc.RouterClient
 	wg       sync.WaitGroup
+	quit     chan struct{}
 	params   *chaincfg.Params
 	adminMac serializedMacaroon
 }
@@ -363,11 +626,23 @@ func newLightningClient(conn *grpc.ClientConn,
 
 	return &lightningClient{
 		client:   lnrpc.NewLightningClient(conn),
+		router:   routerrpc.NewRouterClient(conn),
+		quit:     make(chan struct{}),
 		params:   params,
 		adminMac: adminMac,
 	}
 }
 
+// PaymentUpdate is an intermediate update on the progress of a payment.
+type PaymentUpdate struct {
+	// Status is the aggregated status of the payment.
+	Status PaymentStatus
+
+	// Htlcs is the set of all htlc attempts made so far, including their
+	// routes and the failures of failed attempts.
+	Htlcs []*lnrpc.HTLCAttempt
+}
+
 // PaymentResult signals the result of a payment.
 type PaymentResult struct {
 	Err      error
@@ -376,6 +651,12 @@ type PaymentResult struct {
 	PaidAmt  btcutil.Amount
 }
 
+// stop signals all goroutines of the client to exit, even if they are
+// blocked delivering updates to a caller that stopped reading.
+func (s *lightningClient) stop() {
+	close(s.quit)
+}
+
 func (s *lightningClient) WaitForFinished() {
 	s.wg.Wait()
 }
@@ -395,6 +676,69 @@ func (s *lightningClient) ConfirmedWalletBalance(ctx context.Context) (
 	return btcutil.Amount(resp.ConfirmedBalance), nil
 }
 
+// WalletBalance describes the on chain balance of our wallet.
+type WalletBalance struct {
+	// Confirmed is the balance of the confirmed outputs of our wallet.
+	Confirmed btcutil.Amount
+
+	// Unconfirmed is the balance of the unconfirmed outputs of our
+	// wallet.
+	Unconfirmed btcutil.Amount
+}
+
+// WalletBalance returns the confirmed and unconfirmed balance of our wallet.
+func (s *lightningClient) WalletBalance(ctx context.Context) (*WalletBalance,
+	error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.WalletBalance(
+		rpcCtx, &lnrpc.WalletBalanceRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WalletBalance{
+		Confirmed:   btcutil.Amount(resp.ConfirmedBalance),
+		Unconfirmed: btcutil.Amount(resp.UnconfirmedBalance),
+	}, nil
+}
+
+// ChannelBalance describes our balance in channels.
+type ChannelBalance struct {
+	// Balance is the sum of our local balances in open channels.
+	Balance btcutil.Amount
+
+	// PendingBalance is the sum of our local balances in channels that
+	// are pending open.
+	PendingBalance btcutil.Amount
+}
+
+// ChannelBalance returns the sum of our local balances in open and pending
+// channels.
+func (s *lightningClient) ChannelBalance(ctx context.Context) (
+	*ChannelBalance, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.ChannelBalance(
+		rpcCtx, &lnrpc.ChannelBalanceRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelBalance{
+		Balance:        btcutil.Amount(resp.Balance),
+		PendingBalance: btcutil.Amount(resp.PendingOpenBalance),
+	}, nil
+}
+
 func (s *lightningClient) GetInfo(ctx context.Context) (*Info, error) {
 	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
 	defer cancel()
@@ -428,9 +772,6 @@ func (s *lightningClient) EstimateFeeToP2WSH(ctx context.Context,
 	amt btcutil.Amount, confTarget int32) (btcutil.Amount,
 	error) {
 
-	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
-	defer cancel()
-
 	// Generate dummy p2wsh address for fee estimation.
 	wsh := [32]byte{}
 	p2wshAddress, err := btcutil.NewAddressWitnessScriptHash(
@@ -440,20 +781,44 @@ func (s *lightningClient) EstimateFeeToP2WSH(ctx context.Context,
 		return 0, err
 	}
 
+	fee, _, err := s.EstimateFee(
+		ctx, map[string]btcutil.Amount{
+			p2wshAddress.String(): amt,
+		}, confTarget,
+	)
+	return fee, err
+}
+
+// EstimateFee estimates the total fee and fee rate of a transaction that pays
+// to the addresses and amounts provided, confirming within the target number
+// of blocks. The fee rate returned is the effective rate lnd used, at a
+// precision of one sat/vbyte.
+func (s *lightningClient) EstimateFee(ctx context.Context,
+	outputs map[string]btcutil.Amount, confTarget int32) (btcutil.Amount,
+	chainfee.SatPerKVByte, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	addrToAmount := make(map[string]int64, len(outputs))
+	for addr, amt := range outputs {
+		addrToAmount[addr] = int64(amt)
+	}
+
 	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
 	resp, err := s.client.EstimateFee(
 		rpcCtx,
 		&lnrpc.EstimateFeeRequest{
-			TargetConf: confTarget,
-			AddrToAmount: map[string]int64{
-				p2wshAddress.String(): int64(amt),
-			},
+			TargetConf:   confTarget,
+			AddrToAmount: addrToAmount,
 		},
 	)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
-	return btcutil.Amount(resp.FeeSat), nil
+
+	feeRate := chainfee.SatPerKVByte(resp.FeerateSatPerByte * 1000)
+	return btcutil.Amount(resp.FeeSat), feeRate, nil
 }
 
 // PayInvoice pays an invoice.
@@ -469,140 +834,262 @@ func (s *lightningClient) PayInvoice(ctx context.Context, invoice string,
 	go func() {
 		defer s.wg.Done()
 
-		result := s.payInvoice(ctx, invoice, maxFee, outgoingChannel)
-		if result != nil {
-			paymentChan <- *result
-		}
+		paymentChan <- *s.payInvoice(
+			ctx, invoice, maxFee, outgoingChannel, nil,
+		)
 	}()
 
 	return paymentChan
 }
 
-// payInvoice tries to send a payment and returns the final result. If
-// necessary, it will poll lnd for the payment result.
+// PayInvoiceWithUpdates pays an invoice and delivers intermediate updates on
+// the progress of the payment. The update channel is closed before the final
+// result is delivered and must be read until then.
+func (s *lightningClient) PayInvoiceWithUpdates(ctx context.Context,
+	invoice string, maxFee btcutil.Amount, outgoingChannel *uint64) (
+	chan PaymentUpdate, chan PaymentResult) {
+
+	updateChan := make(chan PaymentUpdate)
+
+	// Use buffer to prevent blocking.
+	paymentChan := make(chan PaymentResult, 1)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		onUpdate := func(payment *lnrpc.Payment) error {
+			paymentStatus, err := unmarshallPaymentStatus(payment)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case updateChan <- PaymentUpdate{
+				Status: *paymentStatus,
+				Htlcs:  payment.Htlcs,
+			}:
+				return nil
+
+			case <-ctx.Done():
+				return ctx.Err()
+
+			case <-s.quit:
+				return ErrClientStopped
+			}
+		}
+
+		result := s.payInvoice(
+			ctx, invoice, maxFee, outgoingChannel, onUpdate,
+		)
+		close(updateChan)
+
+		paymentChan <- *result
+	}()
+
+	return updateChan, paymentChan
+}
+
+// payInvoice sends a payment through lnd's router and returns the final
+// result. If the payment was already initiated by a previous call, the
+// existing payment is tracked instead. The optional update function is called
+// for every update of the payment before the final result is returned.
 func (s *lightningClient) payInvoice(ctx context.Context, invoice string,
-	maxFee btcutil.Amount, outgoingChannel *uint64) *PaymentResult {
+	maxFee btcutil.Amount, outgoingChannel *uint64,
+	onUpdate func(*lnrpc.Payment) error) *PaymentResult {
+
+	ctx = s.adminMac.WithMacaroonAuth(ctx)
+
+	req := &routerrpc.SendPaymentRequest{
+		PaymentRequest: invoice,
+		FeeLimitSat:    int64(maxFee),
+		TimeoutSeconds: int32(payInvoiceTimeout.Seconds()),
+	}
+	if outgoingChannel != nil {
+		req.OutgoingChanIds = []uint64{*outgoingChannel}
+	}
 
-	payReq, err := zpay32.Decode(invoice, s.params)
+	// Don't use a timeout context as this call can block for a long time.
+	stream, err := s.router.SendPaymentV2(ctx, req)
 	if err != nil {
-		return &PaymentResult{
-			Err: fmt.Errorf("invoice decode: %v", err),
+		return s.paymentResultFromErr(ctx, err)
+	}
+
+	payment, err := recvFinalPayment(stream, onUpdate)
+
+	// If the payment was already initiated before, lnd refuses to send it
+	// again. In that case we track the existing payment to obtain its
+	// final result.
+	if status.Code(err) == codes.AlreadyExists {
+		var hash lntypes.Hash
+		hash, err = s.decodePaymentHash(ctx, invoice)
+		if err != nil {
+			return s.paymentResultFromErr(ctx, err)
+		}
+
+		log.Infof("Payment %v already initiated, tracking it", hash)
+
+		var trackStream routerrpc.Router_TrackPaymentV2Client
+		trackStream, err = s.router.TrackPaymentV2(
+			ctx, &routerrpc.TrackPaymentRequest{
+				PaymentHash: hash[:],
+			},
+		)
+		if err != nil {
+			return s.paymentResultFromErr(ctx, err)
 		}
+
+		payment, err = recvFinalPayment(trackStream, onUpdate)
+	}
+	if err != nil {
+		return s.paymentResultFromErr(ctx, err)
 	}
 
-	if payReq.MilliSat == nil {
+	if payment.Status == lnrpc.Payment_FAILED {
+		log.Warnf("Payment %v failed: %v", payment.PaymentHash,
+			payment.FailureReason)
+
 		return &PaymentResult{
-			Err: errors.New("no amount in invoice"),
+			Err: fmt.Errorf("payment failed: %v",
+				payment.FailureReason),
 		}
 	}
 
-	hash := lntypes.Hash(*payReq.PaymentHash)
+	log.Infof("Payment %v completed", payment.PaymentHash)
 
-	ctx = s.adminMac.WithMacaroonAuth(ctx)
-	for {
-		// Create no timeout context as this call can block for a long
-		// time.
+	preimage, err := lntypes.MakePreimageFromStr(payment.PaymentPreimage)
+	if err != nil {
+		return &PaymentResult{Err: err}
+	}
 
-		req := &lnrpc.SendRequest{
-			FeeLimit: &lnrpc.FeeLimit{
-				Limit: &lnrpc.FeeLimit_Fixed{
-					Fixed: int64(maxFee),
-				},
-			},
-			PaymentRequest: invoice,
-		}
+	return &PaymentResult{
+		PaidFee:  lnwire.MilliSatoshi(payment.FeeMsat).ToSatoshis(),
+		PaidAmt:  lnwire.MilliSatoshi(payment.ValueMsat).ToSatoshis(),
+		Preimage: preimage,
+	}
+}
 
-		if outgoingChannel != nil {
-			req.OutgoingChanId = *outgoingChannel
+// paymentStream is the stream of payment updates that is returned by both
+// SendPaymentV2 and TrackPaymentV2.
+type paymentStream interface {
+	Recv() (*lnrpc.Payment, error)
+}
+
+// recvFinalPayment receives payment updates from the stream until the
+// payment reaches a final state. If an update function is provided, it is
+// called for every update that is received, including the final one.
+func recvFinalPayment(stream paymentStream,
+	onUpdate func(*lnrpc.Payment) error) (*lnrpc.Payment, error) {
+
+	for {
+		payment, err := stream.Recv()
+		if err == io.EOF {
+			return nil, errors.New("payment stream ended before " +
+				"final state")
+		}
+		if err != nil {
+			return nil, err
 		}
 
-		payResp, err := s.client.SendPaymentSync(ctx, req)
+		if onUpdate != nil {
+			if err := onUpdate(payment); err != nil {
+				return nil, err
+			}
+		}
 
-		if status.Code(err) == codes.Canceled {
-			return nil
+		switch payment.Status {
+		case lnrpc.Payment_SUCCEEDED, lnrpc.Payment_FAILED:
+			return payment, nil
 		}
+	}
+}
 
-		if err == nil {
-			// TODO: Use structured payment error when available,
-			// instead of this britle string matching.
-			switch payResp.PaymentError {
+// paymentResultFromErr returns the payment result for an error of the payment
+// rpcs. If the payment was aborted because the caller canceled the context or
+// the client was stopped, the cancellation is reported instead of the rpc
+// error that it caused.
+func (s *lightningClient) paymentResultFromErr(ctx context.Context,
+	err error) *PaymentResult {
 
-			// Paid successfully.
-			case PaymentResultSuccess:
-				log.Infof(
-					"Payment %v completed", hash,
-				)
+	select {
+	case <-s.quit:
+		return &PaymentResult{Err: ErrClientStopped}
+	default:
+	}
 
-				r := payResp.PaymentRoute
-				preimage, err := lntypes.MakePreimage(
-					payResp.PaymentPreimage,
-				)
-				if err != nil {
-					return &PaymentResult{Err: err}
-				}
-				return &PaymentResult{
-					PaidFee: btcutil.Amount(r.TotalFees),
-					PaidAmt: btcutil.Amount(
-						r.TotalAmt - r.TotalFees,
-					),
-					Preimage: preimage,
-				}
+	if ctx.Err() != nil {
+		return &PaymentResult{Err: ctx.Err()}
+	}
 
-			// Invoice was already paid on a previous run.
-			case PaymentResultAlreadyPaid:
-				log.Infof(
-					"Payment %v already completed", hash,
-				)
+	return &PaymentResult{Err: err}
+}
 
-				// Unfortunately lnd doesn't return the route if
-				// the payment was successful in a previous
-				// call. Assume paid fees 0 and take paid amount
-				// from invoice.
+// decodePaymentHash decodes the payment hash of the invoice using lnd.
+func (s *lightningClient) decodePaymentHash(ctx context.Context,
+	invoice string) (lntypes.Hash, error) {
 
-				return &PaymentResult{
-					PaidFee: 0,
-					PaidAmt: payReq.MilliSat.ToSatoshis(),
-				}
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
 
-			// If the payment is already in flight, we will poll
-			// again later for an outcome.
-			//
-			// TODO: Improve this when lnd expose more API to
-			// tracking existing payments.
-			case PaymentResultInFlight:
-				log.Infof(
-					"Payment %v already in flight", hash,
-				)
+	payReq, err := s.client.DecodePayReq(
+		rpcCtx, &lnrpc.PayReqString{PayReq: invoice},
+	)
+	if err != nil {
+		return lntypes.Hash{}, err
+	}
 
-				time.Sleep(paymentPollInterval)
+	return lntypes.MakeHashFromStr(payReq.PaymentHash)
+}
 
-			// Other errors are transformed into an error struct.
-			default:
-				log.Warnf(
-					"Payment %v failed: %v", hash,
-					payResp.PaymentError,
-				)
+// addInvoiceOptions holds the optional parameters of an AddInvoice call.
+type addInvoiceOptions struct {
+	routeHints [][]zpay32.HopHint
+}
 
-				return &PaymentResult{
-					Err: errors.New(payResp.PaymentError),
-				}
-			}
-		}
+// AddInvoiceOption is a functional option argument that allows setting
+// optional parameters of an invoice that aren't part of the invoice data.
+type AddInvoiceOption func(o *addInvoiceOptions)
+
+// WithRouteHints is an add invoice option that adds explicit route hints to
+// the invoice, in addition to the hints lnd adds for our private channels if
+// the invoice is private.
+func WithRouteHints(routeHints [][]zpay32.HopHint) AddInvoiceOption {
+	return func(o *addInvoiceOptions) {
+		o.routeHints = routeHints
 	}
 }
 
+// AddInvoice adds an invoice to lnd. Route hints for our private channels are
+// only added if the Private flag is set on the invoice data. Note that this
+// flag defaults to false, while earlier versions of this client always added
+// private route hints. Explicit route hints can be added with the
+// WithRouteHints option.
 func (s *lightningClient) AddInvoice(ctx context.Context,
-	in *invoicesrpc.AddInvoiceData) (lntypes.Hash, string, error) {
+	in *invoicesrpc.AddInvoiceData, opts ...AddInvoiceOption) (lntypes.Hash,
+	string, error) {
+
+	options := &addInvoiceOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
 
 	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
 	defer cancel()
 
+	routeHints, err := marshallRouteHints(options.routeHints)
+	if err != nil {
+		return lntypes.Hash{}, "", err
+	}
+
 	rpcIn := &lnrpc.Invoice{
-		Memo:       in.Memo,
-		Value:      int64(in.Value.ToSatoshis()),
-		Expiry:     in.Expiry,
-		CltvExpiry: in.CltvExpiry,
-		Private:    true,
+		Memo:            in.Memo,
+		Value:           int64(in.Value.ToSatoshis()),
+		DescriptionHash: in.DescriptionHash,
+		Expiry:          in.Expiry,
+		FallbackAddr:    in.FallbackAddr,
+		CltvExpiry:      in.CltvExpiry,
+		Private:         in.Private,
+		RouteHints:      routeHints,
 	}
 
 	if in.Preimage != nil {
@@ -658,6 +1145,73 @@ type Invoice struct {
 
 	// IsKeysend indicates whether the invoice was a spontaneous payment.
 	IsKeysend bool
+
+	// AddIndex is the index of the invoice in the order in which invoices
+	// were added.
+	AddIndex uint64
+
+	// SettleIndex is the index of the invoice in the order in which
+	// invoices were settled. It is only set if the invoice is settled.
+	SettleIndex uint64
+
+	// Expiry is the time after creation at which the invoice expires.
+	Expiry time.Duration
+
+	// CltvExpiry is the delta to use for the time-lock of the CLTV
+	// extended to the final hop.
+	CltvExpiry uint64
+
+	// FallbackAddr is an optional on chain fallback address.
+	FallbackAddr string
+
+	// Private indicates whether route hints for private channels were
+	// included in the invoice.
+	Private bool
+
+	// RouteHints contains the route hints included in the invoice.
+	RouteHints [][]zpay32.HopHint
+
+	// Features is the set of feature bits advertised in the invoice.
+	Features []lnwire.FeatureBit
+
+	// Htlcs contains the htlcs that paid to the invoice.
+	Htlcs []InvoiceHtlc
+}
+
+// InvoiceHtlc represents an htlc that paid to an invoice.
+type InvoiceHtlc struct {
+	// ChannelID is the unique channel ID of the channel the htlc arrived
+	// on.
+	ChannelID uint64
+
+	// HtlcIndex is the index of the htlc on the channel.
+	HtlcIndex uint64
+
+	// Amount is the amount of the htlc.
+	Amount lnwire.MilliSatoshi
+
+	// AcceptHeight is the block height at which the htlc was accepted.
+	AcceptHeight int32
+
+	// AcceptTime is the time at which the htlc was accepted.
+	AcceptTime time.Time
+
+	// ResolveTime is the time at which the htlc was resolved. It is the
+	// zero time if the htlc is not yet resolved.
+	ResolveTime time.Time
+
+	// ExpiryHeight is the block height at which the htlc expires.
+	ExpiryHeight int32
+
+	// State is the current state of the htlc.
+	State channeldb.HtlcState
+
+	// CustomRecords contains the custom tlv records of the htlc.
+	CustomRecords map[uint64][]byte
+
+	// MppTotalAmount is the total amount of the mpp payment this htlc is
+	// part of.
+	MppTotalAmount lnwire.MilliSatoshi
 }
 
 // LookupInvoice looks up an invoice in lnd, it will error if the invoice is
@@ -693,6 +1247,21 @@ func unmarshalInvoice(resp *lnrpc.Invoice) (*Invoice, error) {
 		return nil, err
 	}
 
+	routeHints, err := unmarshallRouteHints(resp.RouteHints)
+	if err != nil {
+		return nil, err
+	}
+
+	htlcs := make([]InvoiceHtlc, len(resp.Htlcs))
+	for i, htlc := range resp.Htlcs {
+		invoiceHtlc, err := unmarshalInvoiceHtlc(htlc)
+		if err != nil {
+			return nil, err
+		}
+
+		htlcs[i] = *invoiceHtlc
+	}
+
 	invoice := &Invoice{
 		Preimage:       nil,
 		Hash:           hash,
@@ -702,6 +1271,15 @@ func unmarshalInvoice(resp *lnrpc.Invoice) (*Invoice, error) {
 		AmountPaid:     lnwire.MilliSatoshi(resp.AmtPaidMsat),
 		CreationDate:   time.Unix(resp.CreationDate, 0),
 		IsKeysend:      resp.IsKeysend,
+		AddIndex:       resp.AddIndex,
+		SettleIndex:    resp.SettleIndex,
+		Expiry:         time.Second * time.Duration(resp.Expiry),
+		CltvExpiry:     resp.CltvExpiry,
+		FallbackAddr:   resp.FallbackAddr,
+		Private:        resp.Private,
+		RouteHints:     routeHints,
+		Features:       unmarshalFeatures(resp.Features),
+		Htlcs:          htlcs,
 	}
 
 	switch resp.State {
@@ -738,6 +1316,43 @@ func unmarshalInvoice(resp *lnrpc.Invoice) (*Invoice, error) {
 	return invoice, nil
 }
 
+// unmarshalInvoiceHtlc creates an invoice htlc from the rpc htlc provided.
+func unmarshalInvoiceHtlc(htlc *lnrpc.InvoiceHTLC) (*InvoiceHtlc, error) {
+	invoiceHtlc := &InvoiceHtlc{
+		ChannelID:      htlc.ChanId,
+		HtlcIndex:      htlc.HtlcIndex,
+		Amount:         lnwire.MilliSatoshi(htlc.AmtMsat),
+		AcceptHeight:   htlc.AcceptHeight,
+		AcceptTime:     time.Unix(htlc.AcceptTime, 0),
+		ExpiryHeight:   htlc.ExpiryHeight,
+		CustomRecords:  htlc.CustomRecords,
+		MppTotalAmount: lnwire.MilliSatoshi(htlc.MppTotalAmtMsat),
+	}
+
+	switch htlc.State {
+	case lnrpc.InvoiceHTLCState_ACCEPTED:
+		invoiceHtlc.State = channeldb.HtlcStateAccepted
+
+	case lnrpc.InvoiceHTLCState_SETTLED:
+		invoiceHtlc.State = channeldb.HtlcStateSettled
+
+	case lnrpc.InvoiceHTLCState_CANCELED:
+		invoiceHtlc.State = channeldb.HtlcStateCanceled
+
+	default:
+		return nil, fmt.Errorf("unknown invoice htlc state: %v",
+			htlc.State)
+	}
+
+	// Only set resolve time if it is non-zero, because 0 unix time is not
+	// the same as a zero time struct.
+	if htlc.ResolveTime != 0 {
+		invoiceHtlc.ResolveTime = time.Unix(htlc.ResolveTime, 0)
+	}
+
+	return invoiceHtlc, nil
+}
+
 // ListTransactions returns all known transactions of the backing lnd node.
 func (s *lightningClient) ListTransactions(ctx context.Context, startHeight,
 	endHeight int32) ([]Transaction, error) {
@@ -758,40 +1373,149 @@ func (s *lightningClient) ListTransactions(ctx context.Context, startHeight,
 
 	txs := make([]Transaction, len(resp.Transactions))
 	for i, respTx := range resp.Transactions {
-		rawTx, err := hex.DecodeString(respTx.RawTxHex)
+		tx, err := unmarshalTransaction(respTx)
 		if err != nil {
 			return nil, err
 		}
 
-		var tx wire.MsgTx
-		if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
-			return nil, err
-		}
-
-		txs[i] = Transaction{
-			Tx:            &tx,
-			TxHash:        tx.TxHash().String(),
-			Timestamp:     time.Unix(respTx.TimeStamp, 0),
-			Amount:        btcutil.Amount(respTx.Amount),
-			Fee:           btcutil.Amount(respTx.TotalFees),
-			Confirmations: respTx.NumConfirmations,
-			Label:         respTx.Label,
-		}
+		txs[i] = *tx
 	}
 
 	return txs, nil
 }
 
-// ListChannels retrieves all channels of the backing lnd node.
-func (s *lightningClient) ListChannels(ctx context.Context) (
-	[]ChannelInfo, error) {
+// unmarshalTransaction creates a transaction from the rpc transaction
+// provided.
+func unmarshalTransaction(respTx *lnrpc.Transaction) (*Transaction, error) {
+	rawTx, err := hex.DecodeString(respTx.RawTxHex)
+	if err != nil {
+		return nil, err
+	}
 
-	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
-	defer cancel()
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return nil, err
+	}
+
+	return &Transaction{
+		Tx:            &tx,
+		TxHash:        tx.TxHash().String(),
+		Timestamp:     time.Unix(respTx.TimeStamp, 0),
+		Amount:        btcutil.Amount(respTx.Amount),
+		Fee:           btcutil.Amount(respTx.TotalFees),
+		Confirmations: respTx.NumConfirmations,
+		Label:         respTx.Label,
+	}, nil
+}
+
+// SubscribeTransactions creates a uni-directional stream from the server to
+// the client in which any newly discovered transactions relevant to the wallet
+// are sent over. A transaction is sent once when it is first seen and again
+// once it confirms. If the stream fails, the error is delivered on the error
+// channel and the caller is expected to re-subscribe and reconcile any missed
+// transactions using ListTransactions.
+func (s *lightningClient) SubscribeTransactions(ctx context.Context) (
+	<-chan Transaction, <-chan error, error) {
+
+	txStream, err := s.client.SubscribeTransactions(
+		s.adminMac.WithMacaroonAuth(ctx),
+		&lnrpc.GetTransactionsRequest{},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txChan := make(chan Transaction)
+	errChan := make(chan error, 1)
+
+	// Transaction updates goroutine.
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			rpcTx, err := txStream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			tx, err := unmarshalTransaction(rpcTx)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case txChan <- *tx:
+			case <-ctx.Done():
+				return
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+
+	return txChan, errChan, nil
+}
+
+// ListChannelsOption is a functional option argument that allows filtering
+// the channels returned by ListChannels. The filtering is done by lnd, so
+// callers only pay for unmarshalling the channels they are interested in.
+type ListChannelsOption func(r *lnrpc.ListChannelsRequest)
+
+// WithActiveOnly is a list channels option that only returns active channels.
+func WithActiveOnly() ListChannelsOption {
+	return func(r *lnrpc.ListChannelsRequest) {
+		r.ActiveOnly = true
+	}
+}
+
+// WithInactiveOnly is a list channels option that only returns inactive
+// channels.
+func WithInactiveOnly() ListChannelsOption {
+	return func(r *lnrpc.ListChannelsRequest) {
+		r.InactiveOnly = true
+	}
+}
+
+// WithPublicOnly is a list channels option that only returns public channels.
+func WithPublicOnly() ListChannelsOption {
+	return func(r *lnrpc.ListChannelsRequest) {
+		r.PublicOnly = true
+	}
+}
+
+// WithPrivateOnly is a list channels option that only returns private
+// channels.
+func WithPrivateOnly() ListChannelsOption {
+	return func(r *lnrpc.ListChannelsRequest) {
+		r.PrivateOnly = true
+	}
+}
+
+// WithChannelPeer is a list channels option that only returns the channels
+// we have with the peer provided.
+func WithChannelPeer(peer route.Vertex) ListChannelsOption {
+	return func(r *lnrpc.ListChannelsRequest) {
+		r.Peer = peer[:]
+	}
+}
+
+// ListChannels retrieves all channels of the backing lnd node. The channels
+// returned can be filtered with list channels options.
+func (s *lightningClient) ListChannels(ctx context.Context,
+	opts ...ListChannelsOption) ([]ChannelInfo, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	req := &lnrpc.ListChannelsRequest{}
+	for _, opt := range opts {
+		opt(req)
+	}
 
 	response, err := s.client.ListChannels(
-		s.adminMac.WithMacaroonAuth(rpcCtx),
-		&lnrpc.ListChannelsRequest{},
+		s.adminMac.WithMacaroonAuth(rpcCtx), req,
 	)
 	if err != nil {
 		return nil, err
@@ -799,33 +1523,44 @@ func (s *lightningClient) ListChannels(ctx context.Context) (
 
 	result := make([]ChannelInfo, len(response.Channels))
 	for i, channel := range response.Channels {
-		remoteVertex, err := route.NewVertexFromStr(channel.RemotePubkey)
+		channelInfo, err := newChannelInfo(channel)
 		if err != nil {
 			return nil, err
 		}
 
-		result[i] = ChannelInfo{
-			ChannelPoint:  channel.ChannelPoint,
-			Active:        channel.Active,
-			ChannelID:     channel.ChanId,
-			PubKeyBytes:   remoteVertex,
-			Capacity:      btcutil.Amount(channel.Capacity),
-			LocalBalance:  btcutil.Amount(channel.LocalBalance),
-			RemoteBalance: btcutil.Amount(channel.RemoteBalance),
-			Initiator:     channel.Initiator,
-			Private:       channel.Private,
-			LifeTime: time.Second * time.Duration(
-				channel.Lifetime,
-			),
-			Uptime: time.Second * time.Duration(
-				channel.Uptime,
-			),
-		}
+		result[i] = *channelInfo
 	}
 
 	return result, nil
 }
 
+// newChannelInfo creates a channel info struct from the rpc channel provided.
+func newChannelInfo(channel *lnrpc.Channel) (*ChannelInfo, error) {
+	remoteVertex, err := route.NewVertexFromStr(channel.RemotePubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelInfo{
+		ChannelPoint:  channel.ChannelPoint,
+		Active:        channel.Active,
+		ChannelID:     channel.ChanId,
+		PubKeyBytes:   remoteVertex,
+		Capacity:      btcutil.Amount(channel.Capacity),
+		LocalBalance:  btcutil.Amount(channel.LocalBalance),
+		RemoteBalance: btcutil.Amount(channel.RemoteBalance),
+		Initiator:     channel.Initiator,
+		Private:       channel.Private,
+		LifeTime: time.Second * time.Duration(
+			channel.Lifetime,
+		),
+		Uptime: time.Second * time.Duration(
+			channel.Uptime,
+		),
+		CloseAddr: channel.CloseAddress,
+	}, nil
+}
+
 // PendingChannels contains lnd's channels that are pending open and close.
 type PendingChannels struct {
 	// PendingForceClose contains our channels that have been force closed,
@@ -995,16 +1730,44 @@ func (s *lightningClient) PendingChannels(ctx context.Context) (*PendingChannels
 	return pending, nil
 }
 
-// ClosedChannels returns a list of our closed channels.
-func (s *lightningClient) ClosedChannels(ctx context.Context) ([]ClosedChannel,
-	error) {
+// ClosedChannels returns a list of our closed channels. If close types are
+// provided, only channels that were closed with one of these types are
+// returned.
+func (s *lightningClient) ClosedChannels(ctx context.Context,
+	closeTypes ...CloseType) ([]ClosedChannel, error) {
 
 	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
 	defer cancel()
 
+	req := &lnrpc.ClosedChannelsRequest{}
+	for _, closeType := range closeTypes {
+		switch closeType {
+		case CloseTypeCooperative:
+			req.Cooperative = true
+
+		case CloseTypeLocalForce:
+			req.LocalForce = true
+
+		case CloseTypeRemoteForce:
+			req.RemoteForce = true
+
+		case CloseTypeBreach:
+			req.Breach = true
+
+		case CloseTypeFundingCancelled:
+			req.FundingCanceled = true
+
+		case CloseTypeAbandoned:
+			req.Abandoned = true
+
+		default:
+			return nil, fmt.Errorf("unknown close type: %v",
+				closeType)
+		}
+	}
+
 	response, err := s.client.ClosedChannels(
-		s.adminMac.WithMacaroonAuth(rpcCtx),
-		&lnrpc.ClosedChannelsRequest{},
+		s.adminMac.WithMacaroonAuth(rpcCtx), req,
 	)
 	if err != nil {
 		return nil, err
@@ -1012,42 +1775,153 @@ func (s *lightningClient) ClosedChannels(ctx context.Context) ([]ClosedChannel,
 
 	channels := make([]ClosedChannel, len(response.Channels))
 	for i, channel := range response.Channels {
-		remote, err := route.NewVertexFromStr(channel.RemotePubkey)
+		closedChannel, err := newClosedChannel(channel)
 		if err != nil {
 			return nil, err
 		}
 
-		closeType, err := rpcCloseType(channel.CloseType)
-		if err != nil {
-			return nil, err
-		}
+		channels[i] = *closedChannel
+	}
+
+	return channels, nil
+}
+
+// newClosedChannel creates a closed channel struct from the rpc close summary
+// provided.
+func newClosedChannel(channel *lnrpc.ChannelCloseSummary) (*ClosedChannel,
+	error) {
+
+	remote, err := route.NewVertexFromStr(channel.RemotePubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	closeType, err := rpcCloseType(channel.CloseType)
+	if err != nil {
+		return nil, err
+	}
+
+	openInitiator, err := getInitiator(channel.OpenInitiator)
+	if err != nil {
+		return nil, err
+	}
 
-		openInitiator, err := getInitiator(channel.OpenInitiator)
+	closeInitiator, err := rpcCloseInitiator(
+		channel.CloseInitiator, closeType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resolutions := make([]Resolution, len(channel.Resolutions))
+	for i, rpcResolution := range channel.Resolutions {
+		resolution, err := unmarshalResolution(rpcResolution)
 		if err != nil {
 			return nil, err
 		}
 
-		closeInitiator, err := rpcCloseInitiator(
-			channel.CloseInitiator, closeType,
-		)
+		resolutions[i] = *resolution
+	}
+
+	return &ClosedChannel{
+		ChannelPoint:   channel.ChannelPoint,
+		ChannelID:      channel.ChanId,
+		ClosingTxHash:  channel.ClosingTxHash,
+		CloseType:      closeType,
+		OpenInitiator:  openInitiator,
+		CloseInitiator: closeInitiator,
+		PubKeyBytes:    remote,
+		Capacity:       btcutil.Amount(channel.Capacity),
+		SettledBalance: btcutil.Amount(channel.SettledBalance),
+		Resolutions:    resolutions,
+	}, nil
+}
+
+// unmarshalResolution creates a resolution from the rpc resolution provided.
+func unmarshalResolution(rpcResolution *lnrpc.Resolution) (*Resolution,
+	error) {
+
+	resolutionType, err := rpcResolutionType(rpcResolution.ResolutionType)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome, err := rpcResolutionOutcome(rpcResolution.Outcome)
+	if err != nil {
+		return nil, err
+	}
+
+	var outpoint wire.OutPoint
+	if rpcResolution.Outpoint != nil {
+		hash, err := chainhash.NewHash(rpcResolution.Outpoint.TxidBytes)
 		if err != nil {
 			return nil, err
 		}
 
-		channels[i] = ClosedChannel{
-			ChannelPoint:   channel.ChannelPoint,
-			ChannelID:      channel.ChanId,
-			ClosingTxHash:  channel.ClosingTxHash,
-			CloseType:      closeType,
-			OpenInitiator:  openInitiator,
-			CloseInitiator: closeInitiator,
-			PubKeyBytes:    remote,
-			Capacity:       btcutil.Amount(channel.Capacity),
-			SettledBalance: btcutil.Amount(channel.SettledBalance),
+		outpoint = wire.OutPoint{
+			Hash:  *hash,
+			Index: rpcResolution.Outpoint.OutputIndex,
 		}
 	}
 
-	return channels, nil
+	return &Resolution{
+		Type:      resolutionType,
+		Outcome:   outcome,
+		Outpoint:  outpoint,
+		Amount:    btcutil.Amount(rpcResolution.AmountSat),
+		SweepTxid: rpcResolution.SweepTxid,
+	}, nil
+}
+
+// rpcResolutionType maps a rpc resolution type to our local enum.
+func rpcResolutionType(t lnrpc.ResolutionType) (ResolutionType, error) {
+	switch t {
+	case lnrpc.ResolutionType_TYPE_UNKNOWN:
+		return ResolutionTypeUnknown, nil
+
+	case lnrpc.ResolutionType_ANCHOR:
+		return ResolutionTypeAnchor, nil
+
+	case lnrpc.ResolutionType_INCOMING_HTLC:
+		return ResolutionTypeIncomingHtlc, nil
+
+	case lnrpc.ResolutionType_OUTGOING_HTLC:
+		return ResolutionTypeOutgoingHtlc, nil
+
+	case lnrpc.ResolutionType_COMMIT:
+		return ResolutionTypeCommit, nil
+
+	default:
+		return 0, fmt.Errorf("unknown resolution type: %v", t)
+	}
+}
+
+// rpcResolutionOutcome maps a rpc resolution outcome to our local enum.
+func rpcResolutionOutcome(o lnrpc.ResolutionOutcome) (ResolutionOutcome,
+	error) {
+
+	switch o {
+	case lnrpc.ResolutionOutcome_OUTCOME_UNKNOWN:
+		return ResolutionOutcomeUnknown, nil
+
+	case lnrpc.ResolutionOutcome_CLAIMED:
+		return ResolutionOutcomeClaimed, nil
+
+	case lnrpc.ResolutionOutcome_UNCLAIMED:
+		return ResolutionOutcomeUnclaimed, nil
+
+	case lnrpc.ResolutionOutcome_ABANDONED:
+		return ResolutionOutcomeAbandoned, nil
+
+	case lnrpc.ResolutionOutcome_FIRST_STAGE:
+		return ResolutionOutcomeFirstStage, nil
+
+	case lnrpc.ResolutionOutcome_TIMEOUT:
+		return ResolutionOutcomeTimeout, nil
+
+	default:
+		return 0, fmt.Errorf("unknown resolution outcome: %v", o)
+	}
 }
 
 // rpcCloseType maps a rpc close type to our local enum.
@@ -1436,6 +2310,135 @@ func (s *lightningClient) ChannelBackups(ctx context.Context) ([]byte, error) {
 	return resp.MultiChanBackup.MultiChanBackup, nil
 }
 
+// SubscribeChannelBackups subscribes to updates of the channel backups. Every
+// time a channel is opened or closed, the new encrypted chanbackup.Multi
+// payload of all channels is delivered.
+func (s *lightningClient) SubscribeChannelBackups(ctx context.Context) (
+	<-chan []byte, <-chan error, error) {
+
+	stream, err := s.client.SubscribeChannelBackups(
+		s.adminMac.WithMacaroonAuth(ctx),
+		&lnrpc.ChannelBackupSubscription{},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backupChan := make(chan []byte)
+	errChan := make(chan error, 1)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			snapshot, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			multi := snapshot.MultiChanBackup
+			if multi == nil {
+				errChan <- errors.New("snapshot without " +
+					"multi channel backup")
+				return
+			}
+
+			select {
+			case backupChan <- multi.MultiChanBackup:
+			case <-ctx.Done():
+				return
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+
+	return backupChan, errChan, nil
+}
+
+// VerifyChannelBackups checks that the encrypted chanbackup.Multi payload
+// provided can be decrypted and parsed by lnd.
+func (s *lightningClient) VerifyChannelBackups(ctx context.Context,
+	multiBackup []byte) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	req := &lnrpc.ChanBackupSnapshot{
+		MultiChanBackup: &lnrpc.MultiChanBackup{
+			MultiChanBackup: multiBackup,
+		},
+	}
+	_, err := s.client.VerifyChanBackup(rpcCtx, req)
+
+	return err
+}
+
+// SingleChannelBackup holds the backup of a single channel.
+type SingleChannelBackup struct {
+	// ChannelPoint is the funding outpoint of the backed up channel.
+	ChannelPoint wire.OutPoint
+
+	// Backup is the encrypted chanbackup.Single payload of the channel.
+	Backup []byte
+}
+
+// RestoreChannelBackups restores all channels contained in the encrypted
+// chanbackup.Multi payload provided, as returned by ChannelBackups.
+func (s *lightningClient) RestoreChannelBackups(ctx context.Context,
+	multiBackup []byte) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	req := &lnrpc.RestoreChanBackupRequest{
+		Backup: &lnrpc.RestoreChanBackupRequest_MultiChanBackup{
+			MultiChanBackup: multiBackup,
+		},
+	}
+	_, err := s.client.RestoreChannelBackups(rpcCtx, req)
+
+	return err
+}
+
+// RestoreSingleChannelBackups restores the channels contained in the encrypted
+// chanbackup.Single payloads provided, as returned by ChannelBackup.
+func (s *lightningClient) RestoreSingleChannelBackups(ctx context.Context,
+	backups []SingleChannelBackup) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcBackups := make([]*lnrpc.ChannelBackup, len(backups))
+	for i, backup := range backups {
+		chanPoint := backup.ChannelPoint
+		rpcBackups[i] = &lnrpc.ChannelBackup{
+			ChanPoint: &lnrpc.ChannelPoint{
+				FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+					FundingTxidBytes: chanPoint.Hash[:],
+				},
+				OutputIndex: chanPoint.Index,
+			},
+			ChanBackup: backup.Backup,
+		}
+	}
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	req := &lnrpc.RestoreChanBackupRequest{
+		Backup: &lnrpc.RestoreChanBackupRequest_ChanBackups{
+			ChanBackups: &lnrpc.ChannelBackups{
+				ChanBackups: rpcBackups,
+			},
+		},
+	}
+	_, err := s.client.RestoreChannelBackups(rpcCtx, req)
+
+	return err
+}
+
 // PaymentRequest represents a request for payment from a node.
 type PaymentRequest struct {
 	// Destination is the node that this payment request pays to .
@@ -1459,6 +2462,25 @@ type PaymentRequest struct {
 	// PaymentAddress is the payment address associated with the invoice,
 	// set if the receiver supports mpp.
 	PaymentAddress [32]byte
+
+	// DescriptionHash is the hash of a description attached to the payment
+	// request, if a description hash was used instead of a description.
+	DescriptionHash []byte
+
+	// FallbackAddr is an optional on chain fallback address.
+	FallbackAddr string
+
+	// CltvExpiry is the delta to use for the time-lock of the CLTV
+	// extended to the final hop.
+	CltvExpiry int64
+
+	// RouteHints contains the route hints included in the payment request.
+	RouteHints [][]zpay32.HopHint
+
+	// Features is the set of feature bits advertised in the payment
+	// request. This can be used to check whether the receiver supports
+	// mpp or keysend before attempting a payment.
+	Features []lnwire.FeatureBit
 }
 
 // DecodePaymentRequest decodes a payment request.
@@ -1487,11 +2509,29 @@ func (s *lightningClient) DecodePaymentRequest(ctx context.Context,
 		return nil, err
 	}
 
+	routeHints, err := unmarshallRouteHints(resp.RouteHints)
+	if err != nil {
+		return nil, err
+	}
+
+	var descHash []byte
+	if resp.DescriptionHash != "" {
+		descHash, err = hex.DecodeString(resp.DescriptionHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	paymentReq := &PaymentRequest{
-		Destination: dest,
-		Hash:        hash,
-		Value:       lnwire.MilliSatoshi(resp.NumMsat),
-		Description: resp.Description,
+		Destination:     dest,
+		Hash:            hash,
+		Value:           lnwire.MilliSatoshi(resp.NumMsat),
+		Description:     resp.Description,
+		DescriptionHash: descHash,
+		FallbackAddr:    resp.FallbackAddr,
+		CltvExpiry:      resp.CltvExpiry,
+		RouteHints:      routeHints,
+		Features:        unmarshalFeatures(resp.Features),
 	}
 
 	copy(paymentReq.PaymentAddress[:], resp.PaymentAddr)
@@ -1502,47 +2542,297 @@ func (s *lightningClient) DecodePaymentRequest(ctx context.Context,
 		paymentReq.Timestamp = time.Unix(resp.Timestamp, 0)
 	}
 
+	// The expiry is expressed in seconds relative to the timestamp of the
+	// payment request.
 	if resp.Expiry != 0 {
-		paymentReq.Expiry = time.Unix(resp.Expiry, 0)
+		paymentReq.Expiry = time.Unix(resp.Timestamp+resp.Expiry, 0)
 	}
 
 	return paymentReq, nil
 }
 
-// OpenChannel opens a channel to the peer provided with the amounts specified.
-func (s *lightningClient) OpenChannel(ctx context.Context, peer route.Vertex,
-	localSat, pushSat btcutil.Amount) (*wire.OutPoint, error) {
+// OpenChannelOption is a functional option argument that allows setting
+// optional parameters of a channel open, without forcing existing users of
+// OpenChannel to update their invocation. These are always processed in
+// order, with later options overriding earlier ones.
+type OpenChannelOption func(r *lnrpc.OpenChannelRequest)
+
+// WithPrivateChannel is an open channel option that opens a private channel
+// which is not announced to the network.
+func WithPrivateChannel() OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.Private = true
+	}
+}
 
-	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
-	defer cancel()
+// WithMinHtlc is an open channel option that sets the minimum value of htlcs
+// we accept from the remote party.
+func WithMinHtlc(minHtlc lnwire.MilliSatoshi) OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.MinHtlcMsat = int64(minHtlc)
+	}
+}
 
-	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+// WithRemoteCsvDelay is an open channel option that sets the delay in blocks
+// that the remote party has to wait to claim its funds after a force close. If
+// not set, lnd scales the delay with the channel size.
+func WithRemoteCsvDelay(csvDelay uint32) OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.RemoteCsvDelay = csvDelay
+	}
+}
 
-	chanPoint, err := s.client.OpenChannelSync(
-		rpcCtx, &lnrpc.OpenChannelRequest{
-			NodePubkey:         peer[:],
-			LocalFundingAmount: int64(localSat),
-			PushSat:            int64(pushSat),
-		},
-	)
-	if err != nil {
-		return nil, err
+// WithFundingTargetConf is an open channel option that sets the number of
+// blocks the funding transaction should confirm in. This is used for fee
+// estimation.
+func WithFundingTargetConf(targetConf int32) OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.TargetConf = targetConf
+		r.SatPerByte = 0
 	}
+}
 
-	var hash *chainhash.Hash
-	switch h := chanPoint.FundingTxid.(type) {
-	case *lnrpc.ChannelPoint_FundingTxidBytes:
-		hash, err = chainhash.NewHash(h.FundingTxidBytes)
+// WithFundingSatPerVByte is an open channel option that sets a manual fee rate
+// in sat/vbyte for the funding transaction.
+func WithFundingSatPerVByte(satPerVByte int64) OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.SatPerByte = satPerVByte
+		r.TargetConf = 0
+	}
+}
 
-	case *lnrpc.ChannelPoint_FundingTxidStr:
-		hash, err = chainhash.NewHashFromStr(h.FundingTxidStr)
+// WithFundingMinConfs is an open channel option that sets the minimum number
+// of confirmations each of the inputs used for the funding transaction must
+// have.
+func WithFundingMinConfs(minConfs int32) OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.MinConfs = minConfs
+	}
+}
 
-	default:
-		return nil, fmt.Errorf("unexpected outpoint type: %T",
-			chanPoint.FundingTxid)
+// WithSpendUnconfirmed is an open channel option that allows unconfirmed
+// outputs to be used as inputs for the funding transaction.
+func WithSpendUnconfirmed() OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.SpendUnconfirmed = true
 	}
-	if err != nil {
-		return nil, err
+}
+
+// WithCloseAddress is an open channel option that sets an upfront shutdown
+// address. Our funds are paid out to this address in a cooperative close,
+// which the peer enforces, so it can't be changed for the lifetime of the
+// channel.
+func WithCloseAddress(addr btcutil.Address) OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.CloseAddress = addr.String()
+	}
+}
+
+// WithChanPointShim is an open channel option that funds the channel with a
+// funding transaction that was created outside of lnd. The local funding
+// amount of the open must match the amount of the shim. lnd doesn't publish
+// the funding transaction, which is left to the caller. Opens with a shim
+// return once the channel is pending.
+func WithChanPointShim(shim ChanPointShim) OpenChannelOption {
+	return func(r *lnrpc.OpenChannelRequest) {
+		r.FundingShim = marshallChanPointShim(shim)
+	}
+}
+
+// OpenChannel opens a channel to the peer provided with the amounts specified.
+// Optional channel parameters can be set with the open channel options.
+func (s *lightningClient) OpenChannel(ctx context.Context, peer route.Vertex,
+	localSat, pushSat btcutil.Amount, opts ...OpenChannelOption) (
+	*wire.OutPoint, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+
+	req := &lnrpc.OpenChannelRequest{
+		NodePubkey:         peer[:],
+		LocalFundingAmount: int64(localSat),
+		PushSat:            int64(pushSat),
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	// lnd only takes the funding shim into account in the streaming open
+	// call, OpenChannelSync silently ignores it.
+	if req.FundingShim != nil {
+		return s.openChannelStream(rpcCtx, req)
+	}
+
+	chanPoint, err := s.client.OpenChannelSync(rpcCtx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return getOutPoint(chanPoint)
+}
+
+// openChannelStream opens a channel with the streaming open call and returns
+// the funding outpoint once the channel is pending.
+func (s *lightningClient) openChannelStream(ctx context.Context,
+	req *lnrpc.OpenChannelRequest) (*wire.OutPoint, error) {
+
+	stream, err := s.client.OpenChannel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		pending := update.GetChanPending()
+		if pending == nil {
+			continue
+		}
+
+		hash, err := chainhash.NewHash(pending.Txid)
+		if err != nil {
+			return nil, err
+		}
+
+		return &wire.OutPoint{
+			Hash:  *hash,
+			Index: pending.OutputIndex,
+		}, nil
+	}
+}
+
+// ChanPointShim describes a channel funding output that was created outside
+// of lnd, for example by a protocol that constructs the funding transaction
+// collaboratively.
+type ChanPointShim struct {
+	// Amount is the size of the funding output.
+	Amount btcutil.Amount
+
+	// ChannelPoint is the funding outpoint of the channel.
+	ChannelPoint wire.OutPoint
+
+	// LocalKey is our multisig key of the funding output. If the key is
+	// derived by lnd, the public key can be left empty and is then
+	// derived from the key locator.
+	LocalKey keychain.KeyDescriptor
+
+	// RemoteKey is the multisig key of the peer.
+	RemoteKey *btcec.PublicKey
+
+	// PendingChanID is the pending channel ID of the channel, which is
+	// used to refer to the shim before the channel is confirmed.
+	PendingChanID [32]byte
+
+	// ThawHeight is the height until which the channel can only be
+	// cooperatively closed by the party that created it. Zero means the
+	// channel is not frozen.
+	ThawHeight uint32
+}
+
+// marshallChanPointShim converts a chan point shim to its rpc representation.
+func marshallChanPointShim(shim ChanPointShim) *lnrpc.FundingShim {
+	chanPoint := &lnrpc.ChannelPoint{
+		FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+			FundingTxidBytes: shim.ChannelPoint.Hash[:],
+		},
+		OutputIndex: shim.ChannelPoint.Index,
+	}
+
+	localKey := &lnrpc.KeyDescriptor{
+		KeyLoc: &lnrpc.KeyLocator{
+			KeyFamily: int32(shim.LocalKey.Family),
+			KeyIndex:  int32(shim.LocalKey.Index),
+		},
+	}
+	if pubKey := shim.LocalKey.PubKey; pubKey != nil {
+		localKey.RawKeyBytes = pubKey.SerializeCompressed()
+	}
+
+	var remoteKey []byte
+	if shim.RemoteKey != nil {
+		remoteKey = shim.RemoteKey.SerializeCompressed()
+	}
+
+	return &lnrpc.FundingShim{
+		Shim: &lnrpc.FundingShim_ChanPointShim{
+			ChanPointShim: &lnrpc.ChanPointShim{
+				Amt:           int64(shim.Amount),
+				ChanPoint:     chanPoint,
+				LocalKey:      localKey,
+				RemoteKey:     remoteKey,
+				PendingChanId: shim.PendingChanID[:],
+				ThawHeight:    shim.ThawHeight,
+			},
+		},
+	}
+}
+
+// RegisterFundingShim registers a chan point shim with lnd, so that a
+// channel that is opened to us by the peer with the shim's pending channel ID
+// is funded by the shim's funding output.
+func (s *lightningClient) RegisterFundingShim(ctx context.Context,
+	shim ChanPointShim) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	req := &lnrpc.FundingTransitionMsg{
+		Trigger: &lnrpc.FundingTransitionMsg_ShimRegister{
+			ShimRegister: marshallChanPointShim(shim),
+		},
+	}
+	_, err := s.client.FundingStateStep(rpcCtx, req)
+
+	return err
+}
+
+// CancelFundingShim cancels the funding shim with the pending channel ID
+// provided, so that it is no longer used for channel opens. A channel that
+// is being funded by the shim is canceled too.
+func (s *lightningClient) CancelFundingShim(ctx context.Context,
+	pendingChanID [32]byte) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	req := &lnrpc.FundingTransitionMsg{
+		Trigger: &lnrpc.FundingTransitionMsg_ShimCancel{
+			ShimCancel: &lnrpc.FundingShimCancel{
+				PendingChanId: pendingChanID[:],
+			},
+		},
+	}
+	_, err := s.client.FundingStateStep(rpcCtx, req)
+
+	return err
+}
+
+// getOutPoint converts a rpc channel point to the outpoint it represents.
+func getOutPoint(chanPoint *lnrpc.ChannelPoint) (*wire.OutPoint, error) {
+	var (
+		hash *chainhash.Hash
+		err  error
+	)
+	switch h := chanPoint.FundingTxid.(type) {
+	case *lnrpc.ChannelPoint_FundingTxidBytes:
+		hash, err = chainhash.NewHash(h.FundingTxidBytes)
+
+	case *lnrpc.ChannelPoint_FundingTxidStr:
+		hash, err = chainhash.NewHashFromStr(h.FundingTxidStr)
+
+	default:
+		return nil, fmt.Errorf("unexpected outpoint type: %T",
+			chanPoint.FundingTxid)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return &wire.OutPoint{
@@ -1579,6 +2869,40 @@ func (p *ChannelClosedUpdate) CloseTxid() chainhash.Hash {
 	return p.CloseTx
 }
 
+// CloseChannelOption is a functional option argument that allows setting
+// optional parameters of a channel close, without forcing existing users of
+// CloseChannel to update their invocation. These are always processed in
+// order, with later options overriding earlier ones.
+type CloseChannelOption func(r *lnrpc.CloseChannelRequest)
+
+// WithCloseTargetConf is a close channel option that sets the number of
+// blocks the closing transaction should confirm in. This is used for fee
+// estimation.
+func WithCloseTargetConf(targetConf int32) CloseChannelOption {
+	return func(r *lnrpc.CloseChannelRequest) {
+		r.TargetConf = targetConf
+		r.SatPerByte = 0
+	}
+}
+
+// WithCloseSatPerVByte is a close channel option that sets a manual fee rate
+// in sat/vbyte for the closing transaction.
+func WithCloseSatPerVByte(satPerVByte int64) CloseChannelOption {
+	return func(r *lnrpc.CloseChannelRequest) {
+		r.SatPerByte = satPerVByte
+		r.TargetConf = 0
+	}
+}
+
+// WithDeliveryAddress is a close channel option that sets the address our
+// funds are paid out to in a cooperative close. This is not possible if an
+// upfront shutdown address was set when the channel was opened.
+func WithDeliveryAddress(addr btcutil.Address) CloseChannelOption {
+	return func(r *lnrpc.CloseChannelRequest) {
+		r.DeliveryAddress = addr.String()
+	}
+}
+
 // CloseChannel closes the channel provided, returning a channel that will send
 // a stream of close updates, and an error channel which will receive errors if
 // the channel close stream fails. This function starts a goroutine to consume
@@ -1587,12 +2911,12 @@ func (p *ChannelClosedUpdate) CloseTxid() chainhash.Hash {
 // sending an EOF), we close the updates and error channel to signal that there
 // are no more updates to be sent.
 func (s *lightningClient) CloseChannel(ctx context.Context,
-	channel *wire.OutPoint, force bool) (chan CloseChannelUpdate,
-	chan error, error) {
+	channel *wire.OutPoint, force bool, opts ...CloseChannelOption) (
+	chan CloseChannelUpdate, chan error, error) {
 
 	rpcCtx := s.adminMac.WithMacaroonAuth(ctx)
 
-	stream, err := s.client.CloseChannel(rpcCtx, &lnrpc.CloseChannelRequest{
+	req := &lnrpc.CloseChannelRequest{
 		ChannelPoint: &lnrpc.ChannelPoint{
 			FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
 				FundingTxidBytes: channel.Hash[:],
@@ -1600,7 +2924,12 @@ func (s *lightningClient) CloseChannel(ctx context.Context,
 			OutputIndex: channel.Index,
 		},
 		Force: force,
-	})
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	stream, err := s.client.CloseChannel(rpcCtx, req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1609,20 +2938,22 @@ func (s *lightningClient) CloseChannel(ctx context.Context,
 	errChan := make(chan error)
 
 	// sendErr is a helper which sends an error or exits because our caller
-	// context was cancelled.
+	// context was cancelled or the client is stopped.
 	sendErr := func(err error) {
 		select {
 		case errChan <- err:
 		case <-ctx.Done():
+		case <-s.quit:
 		}
 	}
 
 	// sendUpdate is a helper which sends an update or exits because our
-	// caller context was cancelled.
+	// caller context was cancelled or the client is stopped.
 	sendUpdate := func(update CloseChannelUpdate) {
 		select {
 		case updateChan <- update:
 		case <-ctx.Done():
+		case <-s.quit:
 		}
 	}
 
@@ -1691,11 +3022,79 @@ func (s *lightningClient) CloseChannel(ctx context.Context,
 	return updateChan, errChan, nil
 }
 
-// Connect attempts to connect to a peer at the host specified.
+// alreadyConnectedMsg is the error message lnd returns when we connect to a
+// peer that we are connected to already.
+const alreadyConnectedMsg = "already connected to peer"
+
+// ConnectStatus is the outcome of a successful Connect call.
+type ConnectStatus uint8
+
+const (
+	// ConnectStatusConnected indicates that a new connection to the peer
+	// was made, or is being made in the background for permanent peers.
+	ConnectStatusConnected ConnectStatus = iota
+
+	// ConnectStatusAlreadyConnected indicates that we were connected to
+	// the peer already.
+	ConnectStatusAlreadyConnected
+)
+
+// String returns the string representation of a connect status.
+func (c ConnectStatus) String() string {
+	switch c {
+	case ConnectStatusConnected:
+		return "Connected"
+
+	case ConnectStatusAlreadyConnected:
+		return "Already Connected"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// connectOptions holds the optional parameters of a Connect call.
+type connectOptions struct {
+	perm    bool
+	timeout time.Duration
+}
+
+// ConnectOption is a functional option argument that allows setting optional
+// parameters of a Connect call.
+type ConnectOption func(o *connectOptions)
+
+// WithPermanentPeer is a connect option that makes lnd keep a persistent
+// connection to the peer, reconnecting whenever the connection is lost. The
+// connection is made in the background, so the call returns before the peer
+// is connected.
+func WithPermanentPeer() ConnectOption {
+	return func(o *connectOptions) {
+		o.perm = true
+	}
+}
+
+// WithPeerConnectTimeout is a connect option that sets how long we wait for
+// the connection to the peer to be made, instead of the default rpc timeout.
+func WithPeerConnectTimeout(timeout time.Duration) ConnectOption {
+	return func(o *connectOptions) {
+		o.timeout = timeout
+	}
+}
+
+// Connect attempts to connect to a peer at the host specified. Optional
+// connection parameters can be set with the connect options. Being connected
+// to the peer already is reported as a status rather than an error.
 func (s *lightningClient) Connect(ctx context.Context, peer route.Vertex,
-	host string) error {
+	host string, opts ...ConnectOption) (ConnectStatus, error) {
 
-	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	options := &connectOptions{
+		timeout: rpcTimeout,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, options.timeout)
 	defer cancel()
 
 	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
@@ -1705,7 +3104,1303 @@ func (s *lightningClient) Connect(ctx context.Context, peer route.Vertex,
 			Pubkey: peer.String(),
 			Host:   host,
 		},
+		Perm: options.perm,
 	})
+	switch {
+	case err == nil:
+		return ConnectStatusConnected, nil
 
-	return err
+	case strings.Contains(status.Convert(err).Message(),
+		alreadyConnectedMsg):
+
+		return ConnectStatusAlreadyConnected, nil
+
+	default:
+		return 0, err
+	}
+}
+
+// InvoiceSubscription holds the parameters for an invoice subscription.
+type InvoiceSubscription struct {
+	// AddIndex is the add index from which to start receiving new invoice
+	// updates. If specified, all invoices that have been added after this
+	// index are delivered first, followed by any newly added invoices.
+	AddIndex uint64
+
+	// SettleIndex is the settle index from which to start receiving
+	// invoice settlement updates. If specified, all invoices that have been
+	// settled after this index are delivered first, followed by any newly
+	// settled invoices.
+	SettleIndex uint64
+}
+
+// SubscribeInvoices subscribes a client to updates of newly added/settled
+// invoices. An invoice update is delivered for every invoice that is added or
+// settled after the indices provided in the subscription. The caller can
+// cancel the subscription by cancelling the context it was called with.
+func (s *lightningClient) SubscribeInvoices(ctx context.Context,
+	req InvoiceSubscription) (<-chan *Invoice, <-chan error, error) {
+
+	invoiceStream, err := s.client.SubscribeInvoices(
+		s.adminMac.WithMacaroonAuth(ctx),
+		&lnrpc.InvoiceSubscription{
+			AddIndex:    req.AddIndex,
+			SettleIndex: req.SettleIndex,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updateChan := make(chan *Invoice)
+	errChan := make(chan error, 1)
+
+	// Invoice updates goroutine.
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			rpcInvoice, err := invoiceStream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			invoice, err := unmarshalInvoice(rpcInvoice)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case updateChan <- invoice:
+			case <-ctx.Done():
+				return
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+
+	return updateChan, errChan, nil
+}
+
+// ChannelUpdateType encodes the type of update for a channel event.
+type ChannelUpdateType uint8
+
+const (
+	// PendingOpenChannelUpdate indicates that the channel event holds
+	// information about a recently opened channel that is still pending
+	// confirmation.
+	PendingOpenChannelUpdate ChannelUpdateType = iota
+
+	// OpenChannelUpdate indicates that the channel event holds information
+	// about a channel that has been opened.
+	OpenChannelUpdate
+
+	// ClosedChannelUpdate indicates that the channel event holds
+	// information about a closed channel.
+	ClosedChannelUpdate
+
+	// ActiveChannelUpdate indicates that the channel event holds
+	// information about a channel that became active.
+	ActiveChannelUpdate
+
+	// InactiveChannelUpdate indicates that the channel event holds
+	// information about a channel that became inactive.
+	InactiveChannelUpdate
+)
+
+// String returns the string representation of a channel update type.
+func (c ChannelUpdateType) String() string {
+	switch c {
+	case PendingOpenChannelUpdate:
+		return "Pending Open"
+
+	case OpenChannelUpdate:
+		return "Open"
+
+	case ClosedChannelUpdate:
+		return "Closed"
+
+	case ActiveChannelUpdate:
+		return "Active"
+
+	case InactiveChannelUpdate:
+		return "Inactive"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// ChannelEventUpdate holds the data fields and type for a particular channel
+// update event.
+type ChannelEventUpdate struct {
+	// UpdateType encodes the update type. Depending on the type other
+	// members may be filled in.
+	UpdateType ChannelUpdateType
+
+	// ChannelPoints holds the channel point for the updated channel. This
+	// is set for pending open, active and inactive channel updates.
+	ChannelPoints *wire.OutPoint
+
+	// OpenedChannelInfo holds the channel info for a newly opened channel.
+	OpenedChannelInfo *ChannelInfo
+
+	// ClosedChannelInfo holds the channel info for a newly closed channel.
+	ClosedChannelInfo *ClosedChannel
+}
+
+// SubscribeChannelEvents allows a client to subscribe to updates relevant to
+// the state of channels. Events include new active channels, inactive
+// channels, and closed channels. The caller can cancel the subscription by
+// cancelling the context it was called with.
+func (s *lightningClient) SubscribeChannelEvents(ctx context.Context) (
+	<-chan *ChannelEventUpdate, <-chan error, error) {
+
+	stream, err := s.client.SubscribeChannelEvents(
+		s.adminMac.WithMacaroonAuth(ctx),
+		&lnrpc.ChannelEventSubscription{},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updateChan := make(chan *ChannelEventUpdate)
+	errChan := make(chan error, 1)
+
+	// Channel events goroutine.
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			rpcEvent, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			event, err := unmarshalChannelEvent(rpcEvent)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case updateChan <- event:
+			case <-ctx.Done():
+				return
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+
+	return updateChan, errChan, nil
+}
+
+// unmarshalChannelEvent creates a channel event update from the rpc event
+// provided.
+func unmarshalChannelEvent(rpcEvent *lnrpc.ChannelEventUpdate) (
+	*ChannelEventUpdate, error) {
+
+	switch update := rpcEvent.Channel.(type) {
+	case *lnrpc.ChannelEventUpdate_PendingOpenChannel:
+		hash, err := chainhash.NewHash(update.PendingOpenChannel.Txid)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChannelEventUpdate{
+			UpdateType: PendingOpenChannelUpdate,
+			ChannelPoints: &wire.OutPoint{
+				Hash:  *hash,
+				Index: update.PendingOpenChannel.OutputIndex,
+			},
+		}, nil
+
+	case *lnrpc.ChannelEventUpdate_OpenChannel:
+		channelInfo, err := newChannelInfo(update.OpenChannel)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChannelEventUpdate{
+			UpdateType:        OpenChannelUpdate,
+			OpenedChannelInfo: channelInfo,
+		}, nil
+
+	case *lnrpc.ChannelEventUpdate_ClosedChannel:
+		closedChannel, err := newClosedChannel(update.ClosedChannel)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChannelEventUpdate{
+			UpdateType:        ClosedChannelUpdate,
+			ClosedChannelInfo: closedChannel,
+		}, nil
+
+	case *lnrpc.ChannelEventUpdate_ActiveChannel:
+		outpoint, err := getOutPoint(update.ActiveChannel)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChannelEventUpdate{
+			UpdateType:    ActiveChannelUpdate,
+			ChannelPoints: outpoint,
+		}, nil
+
+	case *lnrpc.ChannelEventUpdate_InactiveChannel:
+		outpoint, err := getOutPoint(update.InactiveChannel)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChannelEventUpdate{
+			UpdateType:    InactiveChannelUpdate,
+			ChannelPoints: outpoint,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown channel event update: %T",
+			rpcEvent.Channel)
+	}
+}
+
+// Peer contains information about a peer we are connected to.
+type Peer struct {
+	// Pubkey is the peer's pubkey.
+	Pubkey route.Vertex
+
+	// Address is the host:port of the peer.
+	Address string
+
+	// BytesSent is the total number of bytes we have sent the peer.
+	BytesSent uint64
+
+	// BytesReceived is the total number of bytes we have received from
+	// the peer.
+	BytesReceived uint64
+
+	// Sent is the total amount we have sent the peer.
+	Sent btcutil.Amount
+
+	// Received is the total amount we have received from the peer.
+	Received btcutil.Amount
+
+	// Inbound indicates whether the remote peer initiated the connection.
+	Inbound bool
+
+	// PingTime is the estimated round trip time to this peer.
+	PingTime time.Duration
+
+	// SyncType is the type of graph sync we are currently performing with
+	// this peer.
+	SyncType lnrpc.Peer_SyncType
+
+	// Errors holds the most recent errors lnd encountered with the peer,
+	// oldest first. lnd only keeps a limited number of errors per peer.
+	Errors []PeerError
+}
+
+// PeerError is an error that lnd encountered with a peer.
+type PeerError struct {
+	// Timestamp is the time the error occurred.
+	Timestamp time.Time
+
+	// Error is the error message.
+	Error string
+}
+
+// ListPeers gets a list the peers we are currently connected to.
+func (s *lightningClient) ListPeers(ctx context.Context) ([]Peer, error) {
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.ListPeers(rpcCtx, &lnrpc.ListPeersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]Peer, len(resp.Peers))
+	for i, peer := range resp.Peers {
+		pk, err := route.NewVertexFromStr(peer.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		peers[i] = Peer{
+			Pubkey:        pk,
+			Address:       peer.Address,
+			BytesSent:     peer.BytesSent,
+			BytesReceived: peer.BytesRecv,
+			Sent:          btcutil.Amount(peer.SatSent),
+			Received:      btcutil.Amount(peer.SatRecv),
+			Inbound:       peer.Inbound,
+			PingTime: time.Microsecond * time.Duration(
+				peer.PingTime,
+			),
+			SyncType: peer.SyncType,
+			Errors:   make([]PeerError, len(peer.Errors)),
+		}
+
+		for j, peerErr := range peer.Errors {
+			timestamp := int64(peerErr.Timestamp)
+			peers[i].Errors[j] = PeerError{
+				Timestamp: time.Unix(timestamp, 0),
+				Error:     peerErr.Error,
+			}
+		}
+	}
+
+	return peers, nil
+}
+
+// RoutingPolicy holds the edge routing policy for a channel edge.
+type RoutingPolicy struct {
+	// TimeLockDelta is the required timelock delta for HTLCs forwarded
+	// over the channel.
+	TimeLockDelta uint32
+
+	// MinHtlcMsat is the minimum HTLC value that will be forwarded over
+	// the channel.
+	MinHtlcMsat lnwire.MilliSatoshi
+
+	// MaxHtlcMsat is the maximum HTLC value that will be forwarded over
+	// the channel.
+	MaxHtlcMsat lnwire.MilliSatoshi
+
+	// FeeBaseMsat is the base fee charged regardless of the number of
+	// milli-satoshis sent.
+	FeeBaseMsat lnwire.MilliSatoshi
+
+	// FeeRateMilliMsat is the rate that the node will charge for HTLCs for
+	// each millionth of a satoshi forwarded, in milli-satoshis.
+	FeeRateMilliMsat int64
+
+	// Disabled is true if the edge is disabled.
+	Disabled bool
+
+	// LastUpdate is the last update time for the edge policy.
+	LastUpdate time.Time
+}
+
+// ChannelEdge holds the channel edge information and routing policies.
+type ChannelEdge struct {
+	// ChannelID is the unique channel ID for the channel. The first 3 bytes
+	// are the block height, the next 3 the index within the block, and the
+	// last 2 bytes are the output index for the channel.
+	ChannelID uint64
+
+	// ChannelPoint is the funding outpoint of the channel.
+	ChannelPoint string
+
+	// Capacity is the total amount of funds held in this channel.
+	Capacity btcutil.Amount
+
+	// Node1 is the public key of the first node of the channel.
+	Node1 route.Vertex
+
+	// Node2 is the public key of the second node of the channel.
+	Node2 route.Vertex
+
+	// Node1Policy is the routing policy of the first node, nil if the
+	// policy is not known.
+	Node1Policy *RoutingPolicy
+
+	// Node2Policy is the routing policy of the second node, nil if the
+	// policy is not known.
+	Node2Policy *RoutingPolicy
+}
+
+// Node describes a node in the network.
+type Node struct {
+	// PubKey is the node's pubkey.
+	PubKey route.Vertex
+
+	// LastUpdate is the last update time for the node.
+	LastUpdate time.Time
+
+	// Alias is the node's chosen alias.
+	Alias string
+
+	// Color is the node's chosen color, as a hex string.
+	Color string
+
+	// Addresses is the list of network addresses the node advertises.
+	Addresses []string
+
+	// Features is the set of feature bits the node advertises.
+	Features []lnwire.FeatureBit
+}
+
+// Graph describes our view of the graph.
+type Graph struct {
+	// Nodes is the set of nodes in the channel graph.
+	Nodes []Node
+
+	// Edges is the set of edges in the channel graph.
+	Edges []ChannelEdge
+}
+
+// DescribeGraph returns our view of the graph. If includeUnannounced is set,
+// private channels and channels that are still waiting for announcement are
+// included as well.
+func (s *lightningClient) DescribeGraph(ctx context.Context,
+	includeUnannounced bool) (*Graph, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.DescribeGraph(
+		rpcCtx, &lnrpc.ChannelGraphRequest{
+			IncludeUnannounced: includeUnannounced,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &Graph{
+		Nodes: make([]Node, len(resp.Nodes)),
+		Edges: make([]ChannelEdge, len(resp.Edges)),
+	}
+
+	for i, rpcNode := range resp.Nodes {
+		node, err := unmarshalNode(rpcNode)
+		if err != nil {
+			return nil, err
+		}
+
+		graph.Nodes[i] = *node
+	}
+
+	for i, rpcEdge := range resp.Edges {
+		edge, err := unmarshalChannelEdge(rpcEdge)
+		if err != nil {
+			return nil, err
+		}
+
+		graph.Edges[i] = *edge
+	}
+
+	return graph, nil
+}
+
+// unmarshalNode creates a node from the rpc node provided.
+func unmarshalNode(rpcNode *lnrpc.LightningNode) (*Node, error) {
+	pubKey, err := route.NewVertexFromStr(rpcNode.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, len(rpcNode.Addresses))
+	for i, addr := range rpcNode.Addresses {
+		addresses[i] = addr.Addr
+	}
+
+	return &Node{
+		PubKey:     pubKey,
+		LastUpdate: time.Unix(int64(rpcNode.LastUpdate), 0),
+		Alias:      rpcNode.Alias,
+		Color:      rpcNode.Color,
+		Addresses:  addresses,
+		Features:   unmarshalFeatures(rpcNode.Features),
+	}, nil
+}
+
+// unmarshalFeatures returns the sorted list of feature bits contained in the
+// rpc feature map provided.
+func unmarshalFeatures(
+	rpcFeatures map[uint32]*lnrpc.Feature) []lnwire.FeatureBit {
+
+	features := make([]lnwire.FeatureBit, 0, len(rpcFeatures))
+	for bit := range rpcFeatures {
+		features = append(features, lnwire.FeatureBit(bit))
+	}
+
+	sort.Slice(features, func(i, j int) bool {
+		return features[i] < features[j]
+	})
+
+	return features
+}
+
+// unmarshalRawFeatures returns the sorted list of feature bits contained in
+// the serialized feature vector provided, as used by node updates.
+func unmarshalRawFeatures(rawFeatures []byte) ([]lnwire.FeatureBit, error) {
+	if len(rawFeatures) == 0 {
+		return nil, nil
+	}
+
+	featureVector := lnwire.NewRawFeatureVector()
+	err := featureVector.Decode(bytes.NewReader(rawFeatures))
+	if err != nil {
+		return nil, err
+	}
+
+	var features []lnwire.FeatureBit
+	numBits := featureVector.SerializeSize() * 8
+	for bit := 0; bit < numBits; bit++ {
+		if featureVector.IsSet(lnwire.FeatureBit(bit)) {
+			features = append(features, lnwire.FeatureBit(bit))
+		}
+	}
+
+	return features, nil
+}
+
+// unmarshalChannelEdge creates a channel edge from the rpc edge provided.
+func unmarshalChannelEdge(rpcEdge *lnrpc.ChannelEdge) (*ChannelEdge, error) {
+	node1, err := route.NewVertexFromStr(rpcEdge.Node1Pub)
+	if err != nil {
+		return nil, err
+	}
+
+	node2, err := route.NewVertexFromStr(rpcEdge.Node2Pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelEdge{
+		ChannelID:    rpcEdge.ChannelId,
+		ChannelPoint: rpcEdge.ChanPoint,
+		Capacity:     btcutil.Amount(rpcEdge.Capacity),
+		Node1:        node1,
+		Node2:        node2,
+		Node1Policy:  unmarshalRoutingPolicy(rpcEdge.Node1Policy),
+		Node2Policy:  unmarshalRoutingPolicy(rpcEdge.Node2Policy),
+	}, nil
+}
+
+// unmarshalRoutingPolicy creates a routing policy from the rpc policy
+// provided. A nil policy is returned if the rpc policy is not set.
+func unmarshalRoutingPolicy(policy *lnrpc.RoutingPolicy) *RoutingPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &RoutingPolicy{
+		TimeLockDelta:    policy.TimeLockDelta,
+		MinHtlcMsat:      lnwire.MilliSatoshi(policy.MinHtlc),
+		MaxHtlcMsat:      lnwire.MilliSatoshi(policy.MaxHtlcMsat),
+		FeeBaseMsat:      lnwire.MilliSatoshi(policy.FeeBaseMsat),
+		FeeRateMilliMsat: policy.FeeRateMilliMsat,
+		Disabled:         policy.Disabled,
+		LastUpdate:       time.Unix(int64(policy.LastUpdate), 0),
+	}
+}
+
+// NodeUpdate is an update of a node's announcement.
+type NodeUpdate struct {
+	// PubKey is the node's pubkey.
+	PubKey route.Vertex
+
+	// Alias is the node's chosen alias.
+	Alias string
+
+	// Color is the node's chosen color, as a hex string.
+	Color string
+
+	// Addresses is the list of network addresses the node advertises.
+	Addresses []string
+
+	// Features is the set of feature bits the node advertises.
+	Features []lnwire.FeatureBit
+}
+
+// ChannelEdgeUpdate is an update of the routing policy of one direction of a
+// channel. New channels are announced through an update as well.
+type ChannelEdgeUpdate struct {
+	// ChannelID is the unique channel ID for the channel.
+	ChannelID uint64
+
+	// ChannelPoint is the funding outpoint of the channel.
+	ChannelPoint wire.OutPoint
+
+	// Capacity is the total amount of funds held in this channel.
+	Capacity btcutil.Amount
+
+	// RoutingPolicy is the new routing policy of the advertising node.
+	RoutingPolicy *RoutingPolicy
+
+	// AdvertisingNode is the node that updated its policy.
+	AdvertisingNode route.Vertex
+
+	// ConnectingNode is the other node of the channel.
+	ConnectingNode route.Vertex
+}
+
+// ChannelCloseUpdate signals that a channel was closed and removed from the
+// graph.
+type ChannelCloseUpdate struct {
+	// ChannelID is the unique channel ID for the channel.
+	ChannelID uint64
+
+	// ChannelPoint is the funding outpoint of the channel.
+	ChannelPoint wire.OutPoint
+
+	// Capacity is the total amount of funds held in this channel.
+	Capacity btcutil.Amount
+
+	// ClosedHeight is the height at which the channel was closed.
+	ClosedHeight uint32
+}
+
+// GraphTopologyUpdate is a set of updates of the channel graph.
+type GraphTopologyUpdate struct {
+	// NodeUpdates holds the updated node announcements.
+	NodeUpdates []NodeUpdate
+
+	// ChannelEdgeUpdates holds the new or updated channel policies.
+	ChannelEdgeUpdates []ChannelEdgeUpdate
+
+	// ChannelCloseUpdates holds the channels that were closed.
+	ChannelCloseUpdates []ChannelCloseUpdate
+}
+
+// SubscribeGraph subscribes to updates of the channel graph. The caller can
+// cancel the subscription by cancelling the context it was called with.
+func (s *lightningClient) SubscribeGraph(ctx context.Context) (
+	<-chan *GraphTopologyUpdate, <-chan error, error) {
+
+	stream, err := s.client.SubscribeChannelGraph(
+		s.adminMac.WithMacaroonAuth(ctx),
+		&lnrpc.GraphTopologySubscription{},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updateChan := make(chan *GraphTopologyUpdate)
+	errChan := make(chan error, 1)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			rpcUpdate, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			update, err := unmarshalGraphTopologyUpdate(rpcUpdate)
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case updateChan <- update:
+			case <-ctx.Done():
+				return
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+
+	return updateChan, errChan, nil
+}
+
+// unmarshalGraphTopologyUpdate creates a graph update from the rpc update
+// provided.
+func unmarshalGraphTopologyUpdate(rpcUpdate *lnrpc.GraphTopologyUpdate) (
+	*GraphTopologyUpdate, error) {
+
+	update := &GraphTopologyUpdate{
+		NodeUpdates: make([]NodeUpdate, len(rpcUpdate.NodeUpdates)),
+		ChannelEdgeUpdates: make(
+			[]ChannelEdgeUpdate, len(rpcUpdate.ChannelUpdates),
+		),
+		ChannelCloseUpdates: make(
+			[]ChannelCloseUpdate, len(rpcUpdate.ClosedChans),
+		),
+	}
+
+	for i, rpcNode := range rpcUpdate.NodeUpdates {
+		pubKey, err := route.NewVertexFromStr(rpcNode.IdentityKey)
+		if err != nil {
+			return nil, err
+		}
+
+		features, err := unmarshalRawFeatures(rpcNode.GlobalFeatures)
+		if err != nil {
+			return nil, err
+		}
+
+		update.NodeUpdates[i] = NodeUpdate{
+			PubKey:    pubKey,
+			Alias:     rpcNode.Alias,
+			Color:     rpcNode.Color,
+			Addresses: rpcNode.Addresses,
+			Features:  features,
+		}
+	}
+
+	for i, rpcEdge := range rpcUpdate.ChannelUpdates {
+		chanPoint, err := getOutPoint(rpcEdge.ChanPoint)
+		if err != nil {
+			return nil, err
+		}
+
+		advertisingNode, err := route.NewVertexFromStr(
+			rpcEdge.AdvertisingNode,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		connectingNode, err := route.NewVertexFromStr(
+			rpcEdge.ConnectingNode,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		update.ChannelEdgeUpdates[i] = ChannelEdgeUpdate{
+			ChannelID:    rpcEdge.ChanId,
+			ChannelPoint: *chanPoint,
+			Capacity:     btcutil.Amount(rpcEdge.Capacity),
+			RoutingPolicy: unmarshalRoutingPolicy(
+				rpcEdge.RoutingPolicy,
+			),
+			AdvertisingNode: advertisingNode,
+			ConnectingNode:  connectingNode,
+		}
+	}
+
+	for i, rpcClose := range rpcUpdate.ClosedChans {
+		chanPoint, err := getOutPoint(rpcClose.ChanPoint)
+		if err != nil {
+			return nil, err
+		}
+
+		update.ChannelCloseUpdates[i] = ChannelCloseUpdate{
+			ChannelID:    rpcClose.ChanId,
+			ChannelPoint: *chanPoint,
+			Capacity:     btcutil.Amount(rpcClose.Capacity),
+			ClosedHeight: rpcClose.ClosedHeight,
+		}
+	}
+
+	return update, nil
+}
+
+// GetChanInfo returns the channel info for the passed channel, including the
+// routing policy for both end.
+func (s *lightningClient) GetChanInfo(ctx context.Context, chanID uint64) (
+	*ChannelEdge, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.GetChanInfo(rpcCtx, &lnrpc.ChanInfoRequest{
+		ChanId: chanID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalChannelEdge(resp)
+}
+
+// NodeInfo contains information about a node and its channels.
+type NodeInfo struct {
+	// Node contains information about the node itself.
+	*Node
+
+	// ChannelCount is the total number of public channels the node has
+	// announced.
+	ChannelCount int
+
+	// TotalCapacity is the node's total public channel capacity.
+	TotalCapacity btcutil.Amount
+
+	// Channels contains the node's public channels. This field is only
+	// populated if the info was queried with includeChannels set.
+	Channels []ChannelEdge
+}
+
+// GetNodeInfo returns node info for the pubkey provided. If includeChannels
+// is set, the node's public channels are included in the response.
+func (s *lightningClient) GetNodeInfo(ctx context.Context,
+	pubkey route.Vertex, includeChannels bool) (*NodeInfo, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.GetNodeInfo(rpcCtx, &lnrpc.NodeInfoRequest{
+		PubKey:          pubkey.String(),
+		IncludeChannels: includeChannels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := unmarshalNode(resp.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeInfo := &NodeInfo{
+		Node:          node,
+		ChannelCount:  int(resp.NumChannels),
+		TotalCapacity: btcutil.Amount(resp.TotalCapacity),
+		Channels:      make([]ChannelEdge, len(resp.Channels)),
+	}
+
+	for i, rpcEdge := range resp.Channels {
+		edge, err := unmarshalChannelEdge(rpcEdge)
+		if err != nil {
+			return nil, err
+		}
+
+		nodeInfo.Channels[i] = *edge
+	}
+
+	return nodeInfo, nil
+}
+
+// NodePair represents a directed pair of nodes.
+type NodePair struct {
+	// From is the node the pair starts at.
+	From route.Vertex
+
+	// To is the node the pair ends at.
+	To route.Vertex
+}
+
+// QueryRoutesRequest contains the parameters of a route query.
+type QueryRoutesRequest struct {
+	// PubKey is the target node to which the routes should be found.
+	PubKey route.Vertex
+
+	// AmtMsat is the amount to send expressed in millisatoshis.
+	AmtMsat lnwire.MilliSatoshi
+
+	// FinalCLTVDelta is the CLTV delta to use for the final hop. If zero,
+	// lnd's default is used.
+	FinalCLTVDelta int32
+
+	// MaxFee is the maximum total fee that any of the routes may pay. If
+	// zero, the fee is not limited.
+	MaxFee btcutil.Amount
+
+	// IgnoredNodes is a list of nodes that must not be used in any of the
+	// routes.
+	IgnoredNodes []route.Vertex
+
+	// IgnoredPairs is a list of directed node pairs that must not be used
+	// in any of the routes.
+	IgnoredPairs []NodePair
+
+	// UseMissionControl indicates whether the routes should be computed
+	// taking the probabilities learned by mission control into account.
+	UseMissionControl bool
+
+	// CltvLimit is the maximum total time lock of the route. If zero, no
+	// limit is applied.
+	CltvLimit uint32
+
+	// OutgoingChannel restricts the first hop of the route to the channel
+	// provided. If nil, any channel may be used.
+	OutgoingChannel *uint64
+
+	// LastHop is the pubkey of the last hop of the route. If nil, any hop
+	// may be used.
+	LastHop *route.Vertex
+
+	// RouteHints represents the different routing hints that can be used
+	// to assist in reaching the destination.
+	RouteHints [][]zpay32.HopHint
+}
+
+// QueryRoutesResponse contains the result of a route query.
+type QueryRoutesResponse struct {
+	// Routes is the list of routes found.
+	Routes []Route
+
+	// SuccessProb is the success probability of the returned route(s) as
+	// estimated by mission control.
+	SuccessProb float64
+}
+
+// Hop describes a single hop of a route.
+type Hop struct {
+	// ChannelID is the unique channel ID of the channel used for this hop.
+	ChannelID uint64
+
+	// PubKey is the pubkey of the node at the end of this hop.
+	PubKey route.Vertex
+
+	// Expiry is the absolute timelock of the HTLC this hop receives.
+	Expiry uint32
+
+	// AmtToForwardMsat is the amount that this hop forwards to the next.
+	AmtToForwardMsat lnwire.MilliSatoshi
+
+	// FeeMsat is the fee charged by this hop.
+	FeeMsat lnwire.MilliSatoshi
+
+	// TLVPayload indicates whether the hop uses the tlv onion payload
+	// format.
+	TLVPayload bool
+
+	// MPP holds the mpp record for the final hop, nil if not set.
+	MPP *record.MPP
+
+	// CustomRecords holds the custom tlv records that are sent to this hop.
+	CustomRecords map[uint64][]byte
+}
+
+// Route describes a path to a destination through the network.
+type Route struct {
+	// TotalTimeLock is the cumulative timelock of the route.
+	TotalTimeLock uint32
+
+	// TotalFeesMsat is the sum of the fees paid at each hop.
+	TotalFeesMsat lnwire.MilliSatoshi
+
+	// TotalAmtMsat is the total amount sent along the route, including
+	// fees.
+	TotalAmtMsat lnwire.MilliSatoshi
+
+	// Hops contains the hops of the route, in order.
+	Hops []Hop
+}
+
+// QueryRoutes can query for routes to a given destination.
+func (s *lightningClient) QueryRoutes(ctx context.Context,
+	req QueryRoutesRequest) (*QueryRoutesResponse, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	routeHints, err := marshallRouteHints(req.RouteHints)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcReq := &lnrpc.QueryRoutesRequest{
+		PubKey:            req.PubKey.String(),
+		AmtMsat:           int64(req.AmtMsat),
+		FinalCltvDelta:    req.FinalCLTVDelta,
+		UseMissionControl: req.UseMissionControl,
+		CltvLimit:         req.CltvLimit,
+		RouteHints:        routeHints,
+	}
+
+	// lnd doesn't limit the fee if no fee limit is set.
+	if req.MaxFee != 0 {
+		rpcReq.FeeLimit = &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Fixed{
+				Fixed: int64(req.MaxFee),
+			},
+		}
+	}
+
+	// We index into the request instead of ranging over copies, so that
+	// the slices point into the request rather than the loop variable.
+	for i := range req.IgnoredNodes {
+		rpcReq.IgnoredNodes = append(
+			rpcReq.IgnoredNodes, req.IgnoredNodes[i][:],
+		)
+	}
+
+	for i := range req.IgnoredPairs {
+		pair := &req.IgnoredPairs[i]
+		rpcReq.IgnoredPairs = append(
+			rpcReq.IgnoredPairs, &lnrpc.NodePair{
+				From: pair.From[:],
+				To:   pair.To[:],
+			},
+		)
+	}
+
+	if req.OutgoingChannel != nil {
+		rpcReq.OutgoingChanId = *req.OutgoingChannel
+	}
+
+	if req.LastHop != nil {
+		rpcReq.LastHopPubkey = req.LastHop[:]
+	}
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.QueryRoutes(rpcCtx, rpcReq)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]Route, len(resp.Routes))
+	for i, rpcRoute := range resp.Routes {
+		r, err := unmarshalRoute(rpcRoute)
+		if err != nil {
+			return nil, err
+		}
+
+		routes[i] = *r
+	}
+
+	return &QueryRoutesResponse{
+		Routes:      routes,
+		SuccessProb: resp.SuccessProb,
+	}, nil
+}
+
+// unmarshalRoute creates a route from the rpc route provided.
+func unmarshalRoute(rpcRoute *lnrpc.Route) (*Route, error) {
+	hops := make([]Hop, len(rpcRoute.Hops))
+	for i, rpcHop := range rpcRoute.Hops {
+		pubKey, err := route.NewVertexFromStr(rpcHop.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		hop := Hop{
+			ChannelID: rpcHop.ChanId,
+			PubKey:    pubKey,
+			Expiry:    rpcHop.Expiry,
+			AmtToForwardMsat: lnwire.MilliSatoshi(
+				rpcHop.AmtToForwardMsat,
+			),
+			FeeMsat:       lnwire.MilliSatoshi(rpcHop.FeeMsat),
+			TLVPayload:    rpcHop.TlvPayload,
+			CustomRecords: rpcHop.CustomRecords,
+		}
+
+		if rpcHop.MppRecord != nil {
+			var paymentAddr [32]byte
+			copy(paymentAddr[:], rpcHop.MppRecord.PaymentAddr)
+
+			hop.MPP = record.NewMPP(
+				lnwire.MilliSatoshi(
+					rpcHop.MppRecord.TotalAmtMsat,
+				), paymentAddr,
+			)
+		}
+
+		hops[i] = hop
+	}
+
+	return &Route{
+		TotalTimeLock: rpcRoute.TotalTimeLock,
+		TotalFeesMsat: lnwire.MilliSatoshi(rpcRoute.TotalFeesMsat),
+		TotalAmtMsat:  lnwire.MilliSatoshi(rpcRoute.TotalAmtMsat),
+		Hops:          hops,
+	}, nil
+}
+
+// ChannelFeeReport describes the fee policy of one of our channels.
+type ChannelFeeReport struct {
+	// ChannelID is the unique channel ID of the channel.
+	ChannelID uint64
+
+	// ChannelPoint is the funding outpoint of the channel.
+	ChannelPoint string
+
+	// BaseFeeMsat is the base fee we charge for forwarding over this
+	// channel.
+	BaseFeeMsat lnwire.MilliSatoshi
+
+	// FeePerMil is the amount charged per million satoshis forwarded.
+	FeePerMil int64
+
+	// FeeRate is the fee rate charged per satoshi forwarded, expressed as a
+	// fraction.
+	FeeRate float64
+}
+
+// FeeReport contains the fee policies of our channels and the routing fees
+// we earned.
+type FeeReport struct {
+	// Channels contains the fee policy of each of our channels.
+	Channels []ChannelFeeReport
+
+	// DayFeeSum is the total fees we earned over the last 24 hours.
+	DayFeeSum btcutil.Amount
+
+	// WeekFeeSum is the total fees we earned over the last week.
+	WeekFeeSum btcutil.Amount
+
+	// MonthFeeSum is the total fees we earned over the last month.
+	MonthFeeSum btcutil.Amount
+}
+
+// FeeReport returns the fee policies of all our channels and the fees we
+// earned over the last day, week and month.
+func (s *lightningClient) FeeReport(ctx context.Context) (*FeeReport, error) {
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.FeeReport(rpcCtx, &lnrpc.FeeReportRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make([]ChannelFeeReport, len(resp.ChannelFees))
+	for i, fees := range resp.ChannelFees {
+		channels[i] = ChannelFeeReport{
+			ChannelID:    fees.ChanId,
+			ChannelPoint: fees.ChannelPoint,
+			BaseFeeMsat:  lnwire.MilliSatoshi(fees.BaseFeeMsat),
+			FeePerMil:    fees.FeePerMil,
+			FeeRate:      fees.FeeRate,
+		}
+	}
+
+	return &FeeReport{
+		Channels:    channels,
+		DayFeeSum:   btcutil.Amount(resp.DayFeeSum),
+		WeekFeeSum:  btcutil.Amount(resp.WeekFeeSum),
+		MonthFeeSum: btcutil.Amount(resp.MonthFeeSum),
+	}, nil
+}
+
+// NewAddress generates a new address of the type provided. Supported address
+// types are p2wkh (lnwallet.WitnessPubKey) and np2wkh
+// (lnwallet.NestedWitnessPubKey).
+func (s *lightningClient) NewAddress(ctx context.Context,
+	addrType lnwallet.AddressType) (btcutil.Address, error) {
+
+	var rpcAddrType lnrpc.AddressType
+	switch addrType {
+	case lnwallet.WitnessPubKey:
+		rpcAddrType = lnrpc.AddressType_WITNESS_PUBKEY_HASH
+
+	case lnwallet.NestedWitnessPubKey:
+		rpcAddrType = lnrpc.AddressType_NESTED_PUBKEY_HASH
+
+	default:
+		return nil, fmt.Errorf("unsupported address type: %v",
+			addrType)
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.NewAddress(rpcCtx, &lnrpc.NewAddressRequest{
+		Type: rpcAddrType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return btcutil.DecodeAddress(resp.Address, s.params)
+}
+
+// SendCoinsRequest contains the parameters of an on chain payment.
+type SendCoinsRequest struct {
+	// Addr is the address to send coins to.
+	Addr btcutil.Address
+
+	// Amount is the amount to send. It must be zero if SendAll is set.
+	Amount btcutil.Amount
+
+	// TargetConf is the number of blocks the transaction should confirm
+	// in. This is used for fee estimation and cannot be combined with
+	// SatPerVByte.
+	TargetConf int32
+
+	// SatPerVByte is a manual fee rate in sat/vbyte to use for the
+	// transaction. This cannot be combined with TargetConf.
+	SatPerVByte int64
+
+	// SendAll indicates that all coins in the wallet should be swept to
+	// the address provided.
+	SendAll bool
+
+	// Label is an optional label for the transaction.
+	Label string
+}
+
+// SendCoins sends the amount provided to the address provided. It returns the
+// txid of the transaction that was published.
+func (s *lightningClient) SendCoins(ctx context.Context,
+	req SendCoinsRequest) (string, error) {
+
+	if req.Addr == nil {
+		return "", errors.New("address must be set")
+	}
+
+	if req.TargetConf != 0 && req.SatPerVByte != 0 {
+		return "", errors.New("only one of target conf and fee rate " +
+			"may be set")
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.SendCoins(rpcCtx, &lnrpc.SendCoinsRequest{
+		Addr:       req.Addr.String(),
+		Amount:     int64(req.Amount),
+		TargetConf: req.TargetConf,
+		SatPerByte: req.SatPerVByte,
+		SendAll:    req.SendAll,
+		Label:      req.Label,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Txid, nil
+}
+
+// StopDaemon requests lnd to initiate a graceful shutdown. The call returns as
+// soon as lnd has acknowledged the request, the shutdown itself happens
+// asynchronously.
+func (s *lightningClient) StopDaemon(ctx context.Context) error {
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	_, err := s.client.StopDaemon(rpcCtx, &lnrpc.StopRequest{})
+
+	return err
+}
+
+// RecoveryInfo contains information about the wallet recovery process.
+type RecoveryInfo struct {
+	// RecoveryMode is true if the wallet is in recovery mode.
+	RecoveryMode bool
+
+	// RecoveryFinished is true if the wallet recovery process has
+	// finished.
+	RecoveryFinished bool
+
+	// Progress is the recovery progress, ranging from 0 to 1.
+	Progress float64
+}
+
+// GetRecoveryInfo returns information about the wallet recovery process.
+func (s *lightningClient) GetRecoveryInfo(ctx context.Context) (*RecoveryInfo,
+	error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = s.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := s.client.GetRecoveryInfo(
+		rpcCtx, &lnrpc.GetRecoveryInfoRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecoveryInfo{
+		RecoveryMode:     resp.RecoveryMode,
+		RecoveryFinished: resp.RecoveryFinished,
+		Progress:         resp.Progress,
+	}, nil
 }
@@ -0,0 +1,108 @@
+package lndclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"google.golang.org/grpc"
+)
+
+// openChannelStream is an open channel update stream that returns a fixed
+// list of updates.
+type openChannelStream struct {
+	lnrpc.Lightning_OpenChannelClient
+
+	updates []*lnrpc.OpenStatusUpdate
+}
+
+func (o *openChannelStream) Recv() (*lnrpc.OpenStatusUpdate, error) {
+	update := o.updates[0]
+	o.updates = o.updates[1:]
+
+	return update, nil
+}
+
+// openChannelLightning is a lightning rpc client that records the open
+// channel requests it receives.
+type openChannelLightning struct {
+	lnrpc.LightningClient
+
+	stream       *openChannelStream
+	streamReq    *lnrpc.OpenChannelRequest
+	syncRequests int
+}
+
+func (o *openChannelLightning) OpenChannel(_ context.Context,
+	req *lnrpc.OpenChannelRequest, _ ...grpc.CallOption) (
+	lnrpc.Lightning_OpenChannelClient, error) {
+
+	o.streamReq = req
+	return o.stream, nil
+}
+
+func (o *openChannelLightning) OpenChannelSync(_ context.Context,
+	_ *lnrpc.OpenChannelRequest, _ ...grpc.CallOption) (
+	*lnrpc.ChannelPoint, error) {
+
+	o.syncRequests++
+	return &lnrpc.ChannelPoint{}, nil
+}
+
+// TestOpenChannelShim tests that opens with a chan point shim are made with
+// the streaming open call, because lnd ignores the shim in OpenChannelSync.
+func TestOpenChannelShim(t *testing.T) {
+	fundingTxid := chainhash.Hash{1, 2, 3}
+	rpcClient := &openChannelLightning{
+		stream: &openChannelStream{
+			updates: []*lnrpc.OpenStatusUpdate{{
+				Update: &lnrpc.OpenStatusUpdate_ChanPending{
+					ChanPending: &lnrpc.PendingUpdate{
+						Txid:        fundingTxid[:],
+						OutputIndex: 1,
+					},
+				},
+			}},
+		},
+	}
+	client := &lightningClient{client: rpcClient}
+
+	shim := ChanPointShim{
+		Amount: 100000,
+		ChannelPoint: wire.OutPoint{
+			Hash:  fundingTxid,
+			Index: 1,
+		},
+		PendingChanID: [32]byte{9},
+	}
+	chanPoint, err := client.OpenChannel(
+		context.Background(), route.Vertex{1}, shim.Amount, 0,
+		WithChanPointShim(shim),
+	)
+	if err != nil {
+		t.Fatalf("unable to open channel: %v", err)
+	}
+
+	if rpcClient.syncRequests != 0 {
+		t.Fatalf("expected no OpenChannelSync call")
+	}
+
+	rpcShim := rpcClient.streamReq.FundingShim.GetChanPointShim()
+	if rpcShim == nil {
+		t.Fatalf("expected chan point shim in open request")
+	}
+	if rpcShim.Amt != int64(shim.Amount) ||
+		rpcShim.ChanPoint.OutputIndex != shim.ChannelPoint.Index ||
+		rpcShim.PendingChanId[0] != shim.PendingChanID[0] {
+
+		t.Fatalf("unexpected shim in open request: %v", rpcShim)
+	}
+
+	if *chanPoint != shim.ChannelPoint {
+		t.Fatalf("expected channel point %v, got %v",
+			shim.ChannelPoint, chanPoint)
+	}
+}
@@ -2,10 +2,13 @@ package lndclient
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
@@ -15,6 +18,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 )
 
@@ -54,6 +58,10 @@ var (
 	// connected lnd instance does not have all built tags activated that
 	// are required.
 	ErrBuildTagsMissing = errors.New("build tags missing")
+
+	// ErrClientStopped is the error that is returned by calls that were
+	// aborted because the lnd services were stopped.
+	ErrClientStopped = errors.New("lnd client stopped")
 )
 
 // LndServicesConfig holds all configuration settings that are needed to connect
@@ -69,9 +77,48 @@ type LndServicesConfig struct {
 	// MacaroonDir is the directory where all lnd macaroons can be found.
 	MacaroonDir string
 
+	// MacaroonPaths optionally overrides the paths of individual
+	// macaroons. Macaroons without a custom path are read from
+	// MacaroonDir.
+	MacaroonPaths MacaroonPaths
+
+	// CustomMacaroonPath is the optional path to a single macaroon that is
+	// used for all calls to lnd. If set, MacaroonDir and MacaroonPaths are
+	// ignored. The macaroon needs to carry the permissions for all calls
+	// that are made, but at least the info read permission that is needed
+	// to check the lnd version on connect.
+	CustomMacaroonPath string
+
+	// CustomMacaroonHex is an optional hex encoded macaroon that is used
+	// for all calls to lnd, the same way as CustomMacaroonPath. This
+	// allows passing the macaroon from memory, for example from a secrets
+	// manager, without writing it to disk. It takes precedence over
+	// CustomMacaroonPath.
+	CustomMacaroonHex string
+
 	// TLSPath is the path to lnd's TLS certificate file.
 	TLSPath string
 
+	// TLSData is lnd's PEM encoded TLS certificate. If set, it is used
+	// instead of reading the certificate from TLSPath.
+	TLSData string
+
+	// Insecure disables transport security completely. This must only be
+	// used if the connection to lnd doesn't leave a trusted environment,
+	// for example for local regtest and CI setups or connections through
+	// a custom in-memory dialer.
+	Insecure bool
+
+	// TLSSkipVerify uses TLS but skips the verification of lnd's
+	// certificate. This protects against passive eavesdropping only and
+	// must not be used in production.
+	TLSSkipVerify bool
+
+	// NoMacaroons disables macaroon authentication, for lnd nodes that run
+	// with --no-macaroons. No macaroon files are read and calls are sent
+	// without a macaroon.
+	NoMacaroons bool
+
 	// CheckVersion is the minimum version the connected lnd node needs to
 	// be in order to be compatible. The node will be checked against this
 	// when connecting. If no version is supplied, the default minimum
@@ -88,12 +135,23 @@ type LndServicesConfig struct {
 	// block download is still in progress.
 	BlockUntilChainSynced bool
 
+	// BlockUntilUnlocked denotes that the NewLndServices function should
+	// block until lnd's wallet is unlocked instead of failing if it is
+	// still locked. This allows a client to be started together with lnd.
+	BlockUntilUnlocked bool
+
 	// ChainSyncCtx is an optional context that can be passed in when
-	// BlockUntilChainSynced is set to true. If a context is passed in and
-	// its Done() channel sends a message, the wait for chain sync is
-	// aborted. This allows a client to still be shut down properly if lnd
-	// takes a long time to sync.
+	// BlockUntilChainSynced or BlockUntilUnlocked is set to true. If a
+	// context is passed in and its Done() channel sends a message, the wait
+	// is aborted. This allows a client to still be shut down properly if
+	// lnd takes a long time to sync or is never unlocked.
 	ChainSyncCtx context.Context
+
+	// MaxMsgRecvSize is an optional maximum size in bytes of a single gRPC
+	// message the client accepts from lnd. Some responses, for example the
+	// full channel graph on mainnet, can be very large. If no value is
+	// set, a default of 200MiB is used.
+	MaxMsgRecvSize int
 }
 
 // DialerFunc is a function that is used as grpc.WithContextDialer().
@@ -109,6 +167,14 @@ type LndServices struct {
 	Router        RouterClient
 	Versioner     VersionerClient
 
+	// WtClient is the watchtower client. Its calls only succeed if lnd is
+	// built with the wtclientrpc build tag.
+	WtClient WatchtowerClient
+
+	// Watchtower exposes the watchtower run by lnd. Its calls only succeed
+	// if lnd is built with the watchtowerrpc build tag.
+	Watchtower WatchtowerServerClient
+
 	ChainParams *chaincfg.Params
 	NodeAlias   string
 	NodePubkey  [33]byte
@@ -121,12 +187,150 @@ type LndServices struct {
 type GrpcLndServices struct {
 	LndServices
 
-	cleanup func()
+	conn     *grpc.ClientConn
+	cleanup  func()
+	stopOnce sync.Once
+	finished chan struct{}
+}
+
+// ConnOption is a functional option argument that allows tuning the gRPC
+// connection to lnd, without forcing existing users of NewLndServices to
+// update their invocation. These are always processed in order, with later
+// options overriding earlier ones.
+type ConnOption func(*connOptions)
+
+// connOptions is a set of options that configure the gRPC connection created
+// by NewLndServices.
+type connOptions struct {
+	// dialOptions are additional gRPC dial options that are appended to
+	// the ones lndclient sets itself.
+	dialOptions []grpc.DialOption
+
+	// keepalive holds the keepalive parameters of the connection. If nil,
+	// the gRPC defaults are used.
+	keepalive *keepalive.ClientParameters
+
+	// maxMsgSendSize is the maximum size in bytes of a single message
+	// sent to lnd. If zero, the gRPC default is used.
+	maxMsgSendSize int
+
+	// maxMsgRecvSize is the maximum size in bytes of a single message
+	// received from lnd. If zero, the configured or default size is used.
+	maxMsgRecvSize int
+
+	// connectTimeout is the time we wait for the connection to be
+	// established. If zero, the connection is established lazily in the
+	// background and NewLndServices does not wait for it.
+	connectTimeout time.Duration
+
+	// dialer overrides the dialer of the configuration if set.
+	dialer DialerFunc
+
+	// unaryInterceptors are the interceptors that wrap every unary call.
+	unaryInterceptors []grpc.UnaryClientInterceptor
+
+	// streamInterceptors are the interceptors that wrap every streaming
+	// call.
+	streamInterceptors []grpc.StreamClientInterceptor
+}
+
+// WithDialOptions is a connection option that adds arbitrary gRPC dial
+// options to the connection. They are applied after the options lndclient
+// sets itself, so they can override those.
+func WithDialOptions(opts ...grpc.DialOption) ConnOption {
+	return func(o *connOptions) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// WithKeepalive is a connection option that sets the keepalive parameters of
+// the connection. Note that lnd rejects clients that ping more often than its
+// configured policy allows.
+func WithKeepalive(params keepalive.ClientParameters) ConnOption {
+	return func(o *connOptions) {
+		o.keepalive = &params
+	}
+}
+
+// WithMaxMsgSendSize is a connection option that sets the maximum size in
+// bytes of a single message sent to lnd.
+func WithMaxMsgSendSize(size int) ConnOption {
+	return func(o *connOptions) {
+		o.maxMsgSendSize = size
+	}
+}
+
+// WithMaxMsgRecvSize is a connection option that sets the maximum size in
+// bytes of a single message received from lnd. It overrides the
+// MaxMsgRecvSize of the configuration.
+func WithMaxMsgRecvSize(size int) ConnOption {
+	return func(o *connOptions) {
+		o.maxMsgRecvSize = size
+	}
+}
+
+// WithConnectTimeout is a connection option that makes NewLndServices block
+// until the connection to lnd is established, or fail once the timeout
+// expires.
+func WithConnectTimeout(timeout time.Duration) ConnOption {
+	return func(o *connOptions) {
+		o.connectTimeout = timeout
+	}
+}
+
+// WithDialer is a connection option that sets a custom dial function. It
+// overrides the Dialer of the configuration.
+func WithDialer(dialer DialerFunc) ConnOption {
+	return func(o *connOptions) {
+		o.dialer = dialer
+	}
+}
+
+// WithUnaryInterceptors is a connection option that adds client interceptors
+// to all unary calls to lnd, for example for logging or metrics. Interceptors
+// are chained in the order they are added, the first one being the outermost.
+func WithUnaryInterceptors(
+	interceptors ...grpc.UnaryClientInterceptor) ConnOption {
+
+	return func(o *connOptions) {
+		o.unaryInterceptors = append(
+			o.unaryInterceptors, interceptors...,
+		)
+	}
+}
+
+// WithStreamInterceptors is a connection option that adds client interceptors
+// to all streaming calls to lnd. Interceptors are chained in the order they
+// are added, the first one being the outermost.
+func WithStreamInterceptors(
+	interceptors ...grpc.StreamClientInterceptor) ConnOption {
+
+	return func(o *connOptions) {
+		o.streamInterceptors = append(
+			o.streamInterceptors, interceptors...,
+		)
+	}
 }
 
 // NewLndServices creates creates a connection to the given lnd instance and
-// creates a set of required RPC services.
-func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
+// creates a set of required RPC services. The gRPC connection can optionally
+// be tuned with connection options.
+func NewLndServices(cfg *LndServicesConfig,
+	opts ...ConnOption) (*GrpcLndServices, error) {
+
+	connOpts := &connOptions{}
+	for _, opt := range opts {
+		opt(connOpts)
+	}
+
+	// Options take precedence over the configuration.
+	if connOpts.dialer != nil {
+		cfg.Dialer = connOpts.dialer
+	}
+	if connOpts.maxMsgRecvSize != 0 {
+		cfg.MaxMsgRecvSize = connOpts.maxMsgRecvSize
+	}
+
 	// We need to use a custom dialer so we can also connect to unix
 	// sockets and not just TCP addresses.
 	if cfg.Dialer == nil {
@@ -175,7 +379,7 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 
 	// Setup connection with lnd
 	log.Infof("Creating lnd connection to %v", cfg.LndAddress)
-	conn, err := getClientConn(cfg)
+	conn, err := getClientConn(cfg, connOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -193,12 +397,67 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 	// macaroon. We don't use the pouch yet because if not all subservers
 	// are enabled, then not all macaroons might be there and the user would
 	// get a more cryptic error message.
-	readonlyMac, err := newSerializedMacaroon(
-		filepath.Join(macaroonDir, defaultReadonlyFilename),
-	)
+	//
+	// If a single custom macaroon was provided, either from memory or as a
+	// file, it is used for all calls, including these checks.
+	//
+	// Without macaroons, we use an empty macaroon which is never added to
+	// the calls.
+	var customMac serializedMacaroon
+	switch {
+	case cfg.NoMacaroons:
+		customMac = noMacaroon
+
+	case cfg.CustomMacaroonHex != "":
+		customMac, err = newSerializedMacaroonFromHex(
+			cfg.CustomMacaroonHex,
+		)
+
+	case cfg.CustomMacaroonPath != "":
+		customMac, err = newSerializedMacaroon(cfg.CustomMacaroonPath)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	readonlyMac := customMac
+	if !cfg.NoMacaroons && readonlyMac == "" {
+		readonlyMac, err = newSerializedMacaroon(macaroonPath(
+			macaroonDir, cfg.MacaroonPaths.Readonly,
+			defaultReadonlyFilename,
+		))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// If requested in the configuration, we wait for the wallet to be
+	// unlocked before running the compatibility checks, which would fail
+	// on a locked wallet.
+	if cfg.BlockUntilUnlocked {
+		log.Infof("Waiting for lnd wallet to be unlocked")
+
+		readonlyClient := newLightningClient(
+			conn, chainParams, readonlyMac,
+		)
+		err := waitForInfo(
+			cfg.ChainSyncCtx, readonlyClient,
+			func(*Info) bool { return true }, isNotReadyErr, nil,
+		)
+		if err != nil {
+			closeErr := conn.Close()
+			if closeErr != nil {
+				log.Errorf("Error closing lnd connection: %v",
+					closeErr)
+			}
+
+			return nil, fmt.Errorf("error waiting for wallet to "+
+				"be unlocked: %v", err)
+		}
+
+		log.Infof("lnd wallet is unlocked")
+	}
+
 	nodeAlias, nodeKey, version, err := checkLndCompatibility(
 		conn, chainParams, readonlyMac, cfg.Network, cfg.CheckVersion,
 	)
@@ -207,10 +466,17 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 	}
 
 	// Now that we've ensured our macaroon directory is set properly, we
-	// can retrieve our full macaroon pouch from the directory.
-	macaroons, err := newMacaroonPouch(macaroonDir)
-	if err != nil {
-		return nil, fmt.Errorf("unable to obtain macaroons: %v", err)
+	// can retrieve our full macaroon pouch from the directory, or use the
+	// single custom macaroon if one was provided.
+	macaroons := newSingleMacaroonPouch(customMac)
+	if !cfg.NoMacaroons && customMac == "" {
+		macaroons, err = newMacaroonPouch(
+			macaroonDir, cfg.MacaroonPaths,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to obtain macaroons: %v",
+				err)
+		}
 	}
 
 	// With the macaroons loaded and the version checked, we can now create
@@ -227,6 +493,10 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 	invoicesClient := newInvoicesClient(conn, macaroons.invoiceMac)
 	routerClient := newRouterClient(conn, macaroons.routerMac)
 	versionerClient := newVersionerClient(conn, macaroons.readonlyMac)
+	watchtowerClient := newWatchtowerClient(conn, macaroons.adminMac)
+	watchtowerServer := newWatchtowerServerClient(
+		conn, macaroons.readonlyMac,
+	)
 
 	cleanup := func() {
 		log.Debugf("Closing lnd connection")
@@ -235,6 +505,14 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 			log.Errorf("Error closing client connection: %v", err)
 		}
 
+		// Closing the connection ends all streams, but goroutines
+		// could still be blocked delivering updates to callers that
+		// stopped reading. We signal those to exit too.
+		lightningClient.stop()
+		notifierClient.stop()
+		invoicesClient.stop()
+		routerClient.stop()
+
 		log.Debugf("Wait for client to finish")
 		lightningClient.WaitForFinished()
 
@@ -244,6 +522,9 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 		log.Debugf("Wait for invoices to finish")
 		invoicesClient.WaitForFinished()
 
+		log.Debugf("Wait for router to finish")
+		routerClient.WaitForFinished()
+
 		log.Debugf("Lnd services finished")
 	}
 
@@ -256,13 +537,17 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 			Invoices:      invoicesClient,
 			Router:        routerClient,
 			Versioner:     versionerClient,
+			WtClient:      watchtowerClient,
+			Watchtower:    watchtowerServer,
 			ChainParams:   chainParams,
 			NodeAlias:     nodeAlias,
 			NodePubkey:    nodeKey,
 			Version:       version,
 			macaroons:     macaroons,
 		},
-		cleanup: cleanup,
+		conn:     conn,
+		cleanup:  cleanup,
+		finished: make(chan struct{}),
 	}
 
 	log.Infof("Using network %v", cfg.Network)
@@ -275,7 +560,7 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 		log.Infof("Waiting for lnd to be fully synced to its chain " +
 			"backend, this might take a while")
 
-		err := services.waitForChainSync(cfg.ChainSyncCtx)
+		err := services.WaitForSync(cfg.ChainSyncCtx, false, nil)
 		if err != nil {
 			cleanup()
 			return nil, fmt.Errorf("error waiting for chain to "+
@@ -291,61 +576,127 @@ func NewLndServices(cfg *LndServicesConfig) (*GrpcLndServices, error) {
 // Close closes the lnd connection and waits for all sub server clients to
 // finish their goroutines.
 func (s *GrpcLndServices) Close() {
-	s.cleanup()
+	_ = s.Stop(context.Background())
+}
 
-	log.Debugf("Lnd services finished")
+// Stop closes the lnd connection, which cancels all outstanding streams and
+// payments, and waits for all sub server clients to finish their goroutines.
+// If the context is done before all goroutines finished, Stop returns with an
+// error. Stop can be called multiple times, for example to wait again after a
+// timeout.
+func (s *GrpcLndServices) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		go func() {
+			s.cleanup()
+			close(s.finished)
+		}()
+	})
+
+	select {
+	case <-s.finished:
+		log.Debugf("Lnd services finished")
+		return nil
+
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for lnd services to "+
+			"finish: %w", ctx.Err())
+	}
 }
 
-// waitForChainSync waits and blocks until the connected lnd node is fully
-// synced to its chain backend. This could theoretically take hours if the
-// initial block download is still in progress.
-func (s *GrpcLndServices) waitForChainSync(ctx context.Context) error {
-	mainCtx := ctx
-	if mainCtx == nil {
-		mainCtx = context.Background()
-	}
-
-	// We spawn a goroutine that polls in regular intervals and reports back
-	// once the chain is ready (or something went wrong). If the chain is
-	// already synced, this should return almost immediately.
-	update := make(chan error)
-	go func() {
-		for {
-			// The GetInfo call can take a while. But if it takes
-			// too long, that can be a sign of something being wrong
-			// with the node. That's why we don't wait any longer
-			// than a few seconds for each individual GetInfo call.
-			ctxt, cancel := context.WithTimeout(mainCtx, rpcTimeout)
-			info, err := s.Client.GetInfo(ctxt)
-			if err != nil {
-				cancel()
-				update <- fmt.Errorf("error in GetInfo call: "+
-					"%v", err)
-				return
-			}
-			cancel()
+// SyncProgressFunc is a callback that is invoked with the latest node info
+// on every poll while waiting for lnd to become ready.
+type SyncProgressFunc func(info *Info)
+
+// WaitForSync waits and blocks until the connected lnd node is fully synced
+// to its chain backend and, if waitForGraph is set, also to the channel
+// graph. This could theoretically take hours if the initial block download
+// is still in progress. The optional progress callback is invoked with the
+// node info of every poll, which allows callers to report the current block
+// height for example. The wait can be aborted by canceling the context.
+func (s *LndServices) WaitForSync(ctx context.Context, waitForGraph bool,
+	progress SyncProgressFunc) error {
+
+	return waitForInfo(ctx, s.Client, func(info *Info) bool {
+		return info.SyncedToChain &&
+			(!waitForGraph || info.SyncedToGraph)
+	}, nil, progress)
+}
 
-			// We're done, deliver a nil update by closing the chan.
-			if info.SyncedToChain {
-				close(update)
-				return
-			}
+// WaitForReady waits and blocks until the connected lnd node is unlocked and
+// fully synced to both its chain backend and the channel graph. Unlike
+// WaitForSync, it tolerates lnd being unreachable or its wallet being locked
+// while waiting, which is the case if lnd is restarted while the client is
+// running. The optional progress callback is invoked with the node info of
+// every poll that reached the unlocked node. The wait can be aborted by
+// canceling the context.
+func (s *LndServices) WaitForReady(ctx context.Context,
+	progress SyncProgressFunc) error {
+
+	return waitForInfo(ctx, s.Client, func(info *Info) bool {
+		return info.SyncedToChain && info.SyncedToGraph
+	}, isNotReadyErr, progress)
+}
+
+// isNotReadyErr returns true if the given error is returned by lnd while it
+// is still starting up or waiting for its wallet to be unlocked. Before the
+// wallet is unlocked, only the wallet unlocker service is registered, so
+// calls to any other service fail with an unimplemented error.
+func isNotReadyErr(err error) bool {
+	switch status.Code(err) {
+	case codes.Unimplemented, codes.Unavailable:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// waitForInfo polls GetInfo in regular intervals until the done function
+// returns true for the returned info. Errors for which the optional tolerate
+// function returns true don't abort the wait but are retried on the next
+// poll.
+func waitForInfo(ctx context.Context, client LightningClient,
+	done func(*Info) bool, tolerate func(error) bool,
+	progress SyncProgressFunc) error {
 
-			select {
-			// If we're not yet done, let's now wait a few seconds.
-			case <-time.After(chainSyncPollInterval):
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for {
+		// The GetInfo call can take a while. But if it takes too long,
+		// that can be a sign of something being wrong with the node.
+		// That's why the client doesn't wait any longer than a few
+		// seconds for each individual GetInfo call.
+		info, err := client.GetInfo(ctx)
+		switch {
+		case err != nil && (tolerate == nil || !tolerate(err)):
+			return fmt.Errorf("error in GetInfo call: %v", err)
+
+		case err != nil:
+			log.Debugf("lnd not ready yet: %v", err)
+
+		default:
+			if progress != nil {
+				progress(info)
+			}
 
-			// If the user cancels the context, we should also
-			// abort the wait.
-			case <-mainCtx.Done():
-				update <- mainCtx.Err()
-				return
+			// We're done if the node reached the desired state.
+			if done(info) {
+				return nil
 			}
 		}
-	}()
 
-	// Wait for either an error or the nil close signal to arrive.
-	return <-update
+		select {
+		// If we're not yet done, let's now wait a few seconds.
+		case <-time.After(chainSyncPollInterval):
+
+		// If the user cancels the context, we should also abort the
+		// wait.
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 }
 
 // checkLndCompatibility makes sure the connected lnd instance is running on the
@@ -510,36 +861,90 @@ var (
 	defaultSignerFilename            = "signer.macaroon"
 	defaultReadonlyFilename          = "readonly.macaroon"
 
-	// maxMsgRecvSize is the largest gRPC message our client will receive.
-	// We set this to 200MiB.
-	maxMsgRecvSize = grpc.MaxCallRecvMsgSize(1 * 1024 * 1024 * 200)
+	// defaultMaxMsgRecvSize is the largest gRPC message our client will
+	// receive by default. We set this to 200MiB.
+	defaultMaxMsgRecvSize = 1 * 1024 * 1024 * 200
+
+	// maxMsgRecvSize is the call option that sets the default largest gRPC
+	// message our client will receive.
+	maxMsgRecvSize = grpc.MaxCallRecvMsgSize(defaultMaxMsgRecvSize)
 )
 
-func getClientConn(cfg *LndServicesConfig) (*grpc.ClientConn, error) {
+func getClientConn(cfg *LndServicesConfig,
+	connOpts *connOptions) (*grpc.ClientConn, error) {
 
 	// Load the specified TLS certificate and build transport credentials
-	// with it.
-	tlsPath := cfg.TLSPath
-	if tlsPath == "" {
-		tlsPath = defaultTLSCertPath
+	// with it, unless transport security is explicitly disabled.
+	transportOpt := grpc.WithInsecure()
+	if !cfg.Insecure {
+		creds, err := getTLSCredentials(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		transportOpt = grpc.WithTransportCredentials(creds)
 	}
 
-	creds, err := credentials.NewClientTLSFromFile(tlsPath, "")
-	if err != nil {
-		return nil, err
+	// Use the default maximum message size unless the user has configured
+	// a custom one.
+	maxRecvSize := maxMsgRecvSize
+	if cfg.MaxMsgRecvSize != 0 {
+		maxRecvSize = grpc.MaxCallRecvMsgSize(cfg.MaxMsgRecvSize)
+	}
+
+	callOpts := []grpc.CallOption{maxRecvSize}
+	if connOpts.maxMsgSendSize != 0 {
+		sendSize := grpc.MaxCallSendMsgSize(connOpts.maxMsgSendSize)
+		callOpts = append(callOpts, sendSize)
 	}
 
 	// Create a dial options array.
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(creds),
+		transportOpt,
 
 		// Use a custom dialer, to allow connections to unix sockets,
 		// in-memory listeners etc, and not just TCP addresses.
 		grpc.WithContextDialer(cfg.Dialer),
-		grpc.WithDefaultCallOptions(maxMsgRecvSize),
+		grpc.WithDefaultCallOptions(callOpts...),
+	}
+
+	if connOpts.keepalive != nil {
+		opts = append(
+			opts, grpc.WithKeepaliveParams(*connOpts.keepalive),
+		)
 	}
 
-	conn, err := grpc.Dial(cfg.LndAddress, opts...)
+	// The error mapping interceptors come first so that they see the
+	// errors after all custom interceptors ran. Custom interceptors still
+	// get the original errors from lnd.
+	unaryInterceptors := append(
+		[]grpc.UnaryClientInterceptor{errorMappingUnaryInterceptor},
+		connOpts.unaryInterceptors...,
+	)
+	streamInterceptors := append(
+		[]grpc.StreamClientInterceptor{errorMappingStreamInterceptor},
+		connOpts.streamInterceptors...,
+	)
+	opts = append(
+		opts, grpc.WithChainUnaryInterceptor(unaryInterceptors...),
+		grpc.WithChainStreamInterceptor(streamInterceptors...),
+	)
+
+	// If a connect timeout is set, we block until the connection is up so
+	// that an unreachable lnd is reported right away.
+	ctx := context.Background()
+	if connOpts.connectTimeout != 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, connOpts.connectTimeout)
+		defer cancel()
+
+		opts = append(opts, grpc.WithBlock())
+	}
+
+	// Custom options are added last so they can override our defaults.
+	opts = append(opts, connOpts.dialOptions...)
+
+	conn, err := grpc.DialContext(ctx, cfg.LndAddress, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to RPC server: %v",
 			err)
@@ -547,3 +952,34 @@ func getClientConn(cfg *LndServicesConfig) (*grpc.ClientConn, error) {
 
 	return conn, nil
 }
+
+// getTLSCredentials builds the transport credentials from the TLS certificate
+// data of the configuration, or from the certificate file if no data is set.
+func getTLSCredentials(
+	cfg *LndServicesConfig) (credentials.TransportCredentials, error) {
+
+	if cfg.TLSSkipVerify {
+		log.Warnf("Skipping verification of lnd's TLS certificate")
+
+		return credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true,
+		}), nil
+	}
+
+	if cfg.TLSData != "" {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(cfg.TLSData)) {
+			return nil, errors.New("unable to parse TLS " +
+				"certificate data")
+		}
+
+		return credentials.NewClientTLSFromCert(certPool, ""), nil
+	}
+
+	tlsPath := cfg.TLSPath
+	if tlsPath == "" {
+		tlsPath = defaultTLSCertPath
+	}
+
+	return credentials.NewClientTLSFromFile(tlsPath, "")
+}
@@ -3,10 +3,13 @@ package lndclient
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 
+	"github.com/lightningnetwork/lnd/macaroons"
 	"google.golang.org/grpc/metadata"
+	macaroon "gopkg.in/macaroon.v2"
 )
 
 // serializedMacaroon is a type that represents a hex-encoded macaroon. We'll
@@ -14,6 +17,10 @@ import (
 // requires that all keys and values be strings.
 type serializedMacaroon string
 
+// noMacaroon is the empty macaroon that is used if lnd runs without macaroon
+// authentication. It is never added to the calls.
+const noMacaroon serializedMacaroon = ""
+
 // newSerializedMacaroon reads a new serializedMacaroon from that target
 // macaroon path. If the file can't be found, then an error is returned.
 func newSerializedMacaroon(macaroonPath string) (serializedMacaroon, error) {
@@ -25,11 +32,100 @@ func newSerializedMacaroon(macaroonPath string) (serializedMacaroon, error) {
 	return serializedMacaroon(hex.EncodeToString(macBytes)), nil
 }
 
+// macaroonConstraintsKey is the context key under which the per-call macaroon
+// constraints are stored.
+type macaroonConstraintsKey struct{}
+
+// WithMacaroonConstraints returns a context that adds the given first-party
+// caveats to the macaroon of every call that is made with it. The caveats are
+// added right before the call is sent, so a timeout constraint such as
+// macaroons.TimeoutConstraint limits the validity of the credential to a short
+// window after the call was made. Constraints of nested contexts are added on
+// top of each other.
+func WithMacaroonConstraints(ctx context.Context,
+	constraints ...macaroons.Constraint) context.Context {
+
+	existing, _ := ctx.Value(macaroonConstraintsKey{}).([]macaroons.Constraint)
+
+	combined := make(
+		[]macaroons.Constraint, 0, len(existing)+len(constraints),
+	)
+	combined = append(combined, existing...)
+	combined = append(combined, constraints...)
+
+	return context.WithValue(ctx, macaroonConstraintsKey{}, combined)
+}
+
+// newSerializedMacaroonFromHex creates a serializedMacaroon from a hex encoded
+// macaroon. The macaroon is decoded to make sure it is valid.
+func newSerializedMacaroonFromHex(macHex string) (serializedMacaroon, error) {
+	macBytes, err := hex.DecodeString(macHex)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode macaroon hex: %v", err)
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return "", fmt.Errorf("unable to decode macaroon: %v", err)
+	}
+
+	return serializedMacaroon(hex.EncodeToString(macBytes)), nil
+}
+
 // WithMacaroonAuth modifies the passed context to include the macaroon KV
 // metadata of the target macaroon. This method can be used to add the macaroon
 // at call time, rather than when the connection to the gRPC server is created.
+// If the context carries macaroon constraints, they are added to the macaroon
+// first.
 func (s serializedMacaroon) WithMacaroonAuth(ctx context.Context) context.Context {
-	return metadata.AppendToOutgoingContext(ctx, "macaroon", string(s))
+	if s == noMacaroon {
+		return ctx
+	}
+
+	constraints, _ := ctx.Value(macaroonConstraintsKey{}).([]macaroons.Constraint)
+	if len(constraints) == 0 {
+		return metadata.AppendToOutgoingContext(ctx, "macaroon", string(s))
+	}
+
+	constrained, err := s.withConstraints(constraints)
+	if err != nil {
+		// We must never fall back to the unconstrained macaroon, so we
+		// send the call without a macaroon and let lnd reject it.
+		log.Errorf("Unable to add macaroon constraints: %v", err)
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(
+		ctx, "macaroon", string(constrained),
+	)
+}
+
+// withConstraints returns a copy of the macaroon with the given first-party
+// caveats added.
+func (s serializedMacaroon) withConstraints(
+	constraints []macaroons.Constraint) (serializedMacaroon, error) {
+
+	macBytes, err := hex.DecodeString(string(s))
+	if err != nil {
+		return "", err
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return "", err
+	}
+
+	constrained, err := macaroons.AddConstraints(mac, constraints...)
+	if err != nil {
+		return "", err
+	}
+
+	constrainedBytes, err := constrained.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	return serializedMacaroon(hex.EncodeToString(constrainedBytes)), nil
 }
 
 // macaroonPouch holds the set of macaroons we need to interact with lnd for
@@ -58,61 +154,121 @@ type macaroonPouch struct {
 	readonlyMac serializedMacaroon
 }
 
+// MacaroonPaths holds optional custom paths for the individual macaroons that
+// are used to talk to lnd. Every path that is set overrides the default file
+// in the macaroon directory for that service. This allows running with less
+// privileged macaroons, for example a readonly macaroon as the main lightning
+// macaroon of a dashboard.
+type MacaroonPaths struct {
+	// Admin is the path to the macaroon used for the main lightning
+	// client and the watchtower client.
+	Admin string
+
+	// Readonly is the path to the macaroon used for the version check and
+	// other read-only calls.
+	Readonly string
+
+	// Invoice is the path to the macaroon used for the invoices
+	// sub-server.
+	Invoice string
+
+	// ChainNotifier is the path to the macaroon used for the
+	// ChainNotifier sub-server.
+	ChainNotifier string
+
+	// WalletKit is the path to the macaroon used for the WalletKit
+	// sub-server.
+	WalletKit string
+
+	// Router is the path to the macaroon used for the router sub-server.
+	Router string
+
+	// Signer is the path to the macaroon used for the Signer sub-server.
+	Signer string
+}
+
+// macaroonPath returns the custom path if one is set and the default file in
+// the macaroon directory otherwise.
+func macaroonPath(macaroonDir, customPath, defaultFilename string) string {
+	if customPath != "" {
+		return customPath
+	}
+
+	return filepath.Join(macaroonDir, defaultFilename)
+}
+
 // newMacaroonPouch returns a new instance of a fully populated macaroonPouch
-// given the directory where all the macaroons are stored.
-func newMacaroonPouch(macaroonDir string) (*macaroonPouch, error) {
+// given the directory where all the macaroons are stored. Any custom paths
+// override the default file of the respective macaroon.
+func newMacaroonPouch(macaroonDir string,
+	paths MacaroonPaths) (*macaroonPouch, error) {
+
 	m := &macaroonPouch{}
 
 	var err error
 
-	m.invoiceMac, err = newSerializedMacaroon(
-		filepath.Join(macaroonDir, defaultInvoiceMacaroonFilename),
-	)
+	m.invoiceMac, err = newSerializedMacaroon(macaroonPath(
+		macaroonDir, paths.Invoice, defaultInvoiceMacaroonFilename,
+	))
 	if err != nil {
 		return nil, err
 	}
 
-	m.chainMac, err = newSerializedMacaroon(
-		filepath.Join(macaroonDir, defaultChainMacaroonFilename),
-	)
+	m.chainMac, err = newSerializedMacaroon(macaroonPath(
+		macaroonDir, paths.ChainNotifier, defaultChainMacaroonFilename,
+	))
 	if err != nil {
 		return nil, err
 	}
 
-	m.signerMac, err = newSerializedMacaroon(
-		filepath.Join(macaroonDir, defaultSignerFilename),
-	)
+	m.signerMac, err = newSerializedMacaroon(macaroonPath(
+		macaroonDir, paths.Signer, defaultSignerFilename,
+	))
 	if err != nil {
 		return nil, err
 	}
 
-	m.walletKitMac, err = newSerializedMacaroon(
-		filepath.Join(macaroonDir, defaultWalletKitMacaroonFilename),
-	)
+	m.walletKitMac, err = newSerializedMacaroon(macaroonPath(
+		macaroonDir, paths.WalletKit, defaultWalletKitMacaroonFilename,
+	))
 	if err != nil {
 		return nil, err
 	}
 
-	m.routerMac, err = newSerializedMacaroon(
-		filepath.Join(macaroonDir, defaultRouterMacaroonFilename),
-	)
+	m.routerMac, err = newSerializedMacaroon(macaroonPath(
+		macaroonDir, paths.Router, defaultRouterMacaroonFilename,
+	))
 	if err != nil {
 		return nil, err
 	}
 
-	m.adminMac, err = newSerializedMacaroon(
-		filepath.Join(macaroonDir, defaultAdminMacaroonFilename),
-	)
+	m.adminMac, err = newSerializedMacaroon(macaroonPath(
+		macaroonDir, paths.Admin, defaultAdminMacaroonFilename,
+	))
 	if err != nil {
 		return nil, err
 	}
 
-	m.readonlyMac, err = newSerializedMacaroon(
-		filepath.Join(macaroonDir, defaultReadonlyFilename),
-	)
+	m.readonlyMac, err = newSerializedMacaroon(macaroonPath(
+		macaroonDir, paths.Readonly, defaultReadonlyFilename,
+	))
 	if err != nil {
 		return nil, err
 	}
 
 	return m, nil
 }
+
+// newSingleMacaroonPouch returns a macaroonPouch that uses the given macaroon
+// for all sub-servers.
+func newSingleMacaroonPouch(mac serializedMacaroon) *macaroonPouch {
+	return &macaroonPouch{
+		invoiceMac:   mac,
+		chainMac:     mac,
+		signerMac:    mac,
+		walletKitMac: mac,
+		routerMac:    mac,
+		adminMac:     mac,
+		readonlyMac:  mac,
+	}
+}
@@ -0,0 +1,67 @@
+package lndclient
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc/metadata"
+	macaroon "gopkg.in/macaroon.v2"
+)
+
+// TestWithMacaroonConstraints makes sure that per-call constraints are added
+// as caveats to the macaroon that is sent with a call, without changing the
+// macaroon of calls that don't carry constraints.
+func TestWithMacaroonConstraints(t *testing.T) {
+	mac, err := macaroon.New(
+		[]byte("root key"), []byte("id"), "lnd", macaroon.LatestVersion,
+	)
+	if err != nil {
+		t.Fatalf("unable to create macaroon: %v", err)
+	}
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to serialize macaroon: %v", err)
+	}
+	serialized := serializedMacaroon(hex.EncodeToString(macBytes))
+
+	// sentCaveats extracts the caveats of the macaroon that would be sent
+	// with the given context.
+	sentCaveats := func(ctx context.Context) []macaroon.Caveat {
+		md, ok := metadata.FromOutgoingContext(
+			serialized.WithMacaroonAuth(ctx),
+		)
+		if !ok || len(md.Get("macaroon")) != 1 {
+			t.Fatalf("expected exactly one macaroon")
+		}
+
+		sentBytes, err := hex.DecodeString(md.Get("macaroon")[0])
+		if err != nil {
+			t.Fatalf("unable to decode macaroon: %v", err)
+		}
+
+		sent := &macaroon.Macaroon{}
+		if err := sent.UnmarshalBinary(sentBytes); err != nil {
+			t.Fatalf("unable to unmarshal macaroon: %v", err)
+		}
+
+		return sent.Caveats()
+	}
+
+	// Without constraints, the macaroon is sent unchanged.
+	if caveats := sentCaveats(context.Background()); len(caveats) != 0 {
+		t.Fatalf("expected no caveats, got %v", len(caveats))
+	}
+
+	// Constraints of nested contexts are combined.
+	ctx := WithMacaroonConstraints(
+		context.Background(), macaroons.TimeoutConstraint(60),
+	)
+	ctx = WithMacaroonConstraints(
+		ctx, macaroons.IPLockConstraint("127.0.0.1"),
+	)
+	if caveats := sentCaveats(ctx); len(caveats) != 2 {
+		t.Fatalf("expected 2 caveats, got %v", len(caveats))
+	}
+}
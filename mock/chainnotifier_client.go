@@ -0,0 +1,85 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+)
+
+// ChainNotifierClient is a configurable mock implementation of the
+// lndclient.ChainNotifierClient interface. Every method calls the function
+// field of the same name with a Func suffix. If that field is nil, the method
+// returns ErrNotImplemented.
+type ChainNotifierClient struct {
+	RegisterBlockEpochNtfnFunc func(context.Context) (chan int32,
+		chan error, error)
+
+	RegisterBlockEpochsFunc func(context.Context) (
+		chan lndclient.BlockEpoch, chan error, error)
+
+	RegisterConfirmationsNtfnFunc func(context.Context, *chainhash.Hash,
+		[]byte, int32, int32,
+		...lndclient.NotifierOption) (chan *chainntnfs.TxConfirmation,
+		chan error, error)
+
+	RegisterSpendNtfnFunc func(context.Context, *wire.OutPoint, []byte,
+		int32) (chan *chainntnfs.SpendDetail, chan error, error)
+}
+
+// A compile-time constraint to ensure ChainNotifierClient satisfies the
+// lndclient.ChainNotifierClient interface.
+var _ lndclient.ChainNotifierClient = (*ChainNotifierClient)(nil)
+
+// RegisterBlockEpochNtfn is part of the lndclient.ChainNotifierClient
+// interface.
+func (m *ChainNotifierClient) RegisterBlockEpochNtfn(ctx context.Context) (
+	chan int32, chan error, error) {
+
+	if m.RegisterBlockEpochNtfnFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.RegisterBlockEpochNtfnFunc(ctx)
+}
+
+// RegisterBlockEpochs is part of the lndclient.ChainNotifierClient interface.
+func (m *ChainNotifierClient) RegisterBlockEpochs(ctx context.Context) (
+	chan lndclient.BlockEpoch, chan error, error) {
+
+	if m.RegisterBlockEpochsFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.RegisterBlockEpochsFunc(ctx)
+}
+
+// RegisterConfirmationsNtfn is part of the lndclient.ChainNotifierClient
+// interface.
+func (m *ChainNotifierClient) RegisterConfirmationsNtfn(ctx context.Context,
+	txid *chainhash.Hash, pkScript []byte, numConfs int32, heightHint int32,
+	opts ...lndclient.NotifierOption) (chan *chainntnfs.TxConfirmation,
+	chan error, error) {
+
+	if m.RegisterConfirmationsNtfnFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.RegisterConfirmationsNtfnFunc(
+		ctx, txid, pkScript, numConfs, heightHint, opts...,
+	)
+}
+
+// RegisterSpendNtfn is part of the lndclient.ChainNotifierClient interface.
+func (m *ChainNotifierClient) RegisterSpendNtfn(ctx context.Context,
+	outpoint *wire.OutPoint, pkScript []byte,
+	heightHint int32) (chan *chainntnfs.SpendDetail, chan error, error) {
+
+	if m.RegisterSpendNtfnFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.RegisterSpendNtfnFunc(ctx, outpoint, pkScript, heightHint)
+}
@@ -0,0 +1,75 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// InvoicesClient is a configurable mock implementation of the
+// lndclient.InvoicesClient interface. Every method calls the function field of
+// the same name with a Func suffix. If that field is nil, the method returns
+// ErrNotImplemented.
+type InvoicesClient struct {
+	SubscribeSingleInvoiceFunc func(context.Context,
+		lntypes.Hash) (<-chan lndclient.InvoiceUpdate, <-chan error,
+		error)
+
+	SettleInvoiceFunc func(context.Context, lntypes.Preimage) error
+
+	CancelInvoiceFunc func(context.Context, lntypes.Hash) error
+
+	AddHoldInvoiceFunc func(context.Context,
+		*invoicesrpc.AddInvoiceData) (string, error)
+}
+
+// A compile-time constraint to ensure InvoicesClient satisfies the
+// lndclient.InvoicesClient interface.
+var _ lndclient.InvoicesClient = (*InvoicesClient)(nil)
+
+// SubscribeSingleInvoice is part of the lndclient.InvoicesClient interface.
+func (m *InvoicesClient) SubscribeSingleInvoice(ctx context.Context,
+	hash lntypes.Hash) (<-chan lndclient.InvoiceUpdate, <-chan error,
+	error) {
+
+	if m.SubscribeSingleInvoiceFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.SubscribeSingleInvoiceFunc(ctx, hash)
+}
+
+// SettleInvoice is part of the lndclient.InvoicesClient interface.
+func (m *InvoicesClient) SettleInvoice(ctx context.Context,
+	preimage lntypes.Preimage) error {
+
+	if m.SettleInvoiceFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.SettleInvoiceFunc(ctx, preimage)
+}
+
+// CancelInvoice is part of the lndclient.InvoicesClient interface.
+func (m *InvoicesClient) CancelInvoice(ctx context.Context,
+	hash lntypes.Hash) error {
+
+	if m.CancelInvoiceFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.CancelInvoiceFunc(ctx, hash)
+}
+
+// AddHoldInvoice is part of the lndclient.InvoicesClient interface.
+func (m *InvoicesClient) AddHoldInvoice(ctx context.Context,
+	in *invoicesrpc.AddInvoiceData) (string, error) {
+
+	if m.AddHoldInvoiceFunc == nil {
+		return "", ErrNotImplemented
+	}
+
+	return m.AddHoldInvoiceFunc(ctx, in)
+}
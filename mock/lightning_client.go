@@ -0,0 +1,649 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// LightningClient is a configurable mock implementation of the
+// lndclient.LightningClient interface. Every method calls the function field of
+// the same name with a Func suffix. If that field is nil, the method returns
+// ErrNotImplemented.
+type LightningClient struct {
+	PayInvoiceFunc func(context.Context, string, btcutil.Amount,
+		*uint64) chan lndclient.PaymentResult
+
+	PayInvoiceWithUpdatesFunc func(context.Context, string, btcutil.Amount,
+		*uint64) (chan lndclient.PaymentUpdate,
+		chan lndclient.PaymentResult)
+
+	GetInfoFunc func(context.Context) (*lndclient.Info, error)
+
+	EstimateFeeToP2WSHFunc func(context.Context, btcutil.Amount,
+		int32) (btcutil.Amount, error)
+
+	EstimateFeeFunc func(context.Context, map[string]btcutil.Amount,
+		int32) (btcutil.Amount, chainfee.SatPerKVByte, error)
+
+	ConfirmedWalletBalanceFunc func(context.Context) (btcutil.Amount, error)
+
+	WalletBalanceFunc func(context.Context) (*lndclient.WalletBalance,
+		error)
+
+	ChannelBalanceFunc func(context.Context) (*lndclient.ChannelBalance,
+		error)
+
+	AddInvoiceFunc func(context.Context, *invoicesrpc.AddInvoiceData,
+		...lndclient.AddInvoiceOption) (lntypes.Hash, string, error)
+
+	LookupInvoiceFunc func(context.Context,
+		lntypes.Hash) (*lndclient.Invoice, error)
+
+	ListTransactionsFunc func(context.Context, int32,
+		int32) ([]lndclient.Transaction, error)
+
+	ListChannelsFunc func(context.Context,
+		...lndclient.ListChannelsOption) ([]lndclient.ChannelInfo,
+		error)
+
+	PendingChannelsFunc func(context.Context) (*lndclient.PendingChannels,
+		error)
+
+	ClosedChannelsFunc func(context.Context,
+		...lndclient.CloseType) ([]lndclient.ClosedChannel, error)
+
+	ForwardingHistoryFunc func(context.Context,
+		lndclient.ForwardingHistoryRequest) (
+		*lndclient.ForwardingHistoryResponse, error)
+
+	ListInvoicesFunc func(context.Context,
+		lndclient.ListInvoicesRequest) (*lndclient.ListInvoicesResponse,
+		error)
+
+	ListPaymentsFunc func(context.Context,
+		lndclient.ListPaymentsRequest) (*lndclient.ListPaymentsResponse,
+		error)
+
+	ChannelBackupFunc func(context.Context, wire.OutPoint) ([]byte, error)
+
+	ChannelBackupsFunc func(context.Context) ([]byte, error)
+
+	SubscribeChannelBackupsFunc func(context.Context) (<-chan []byte,
+		<-chan error, error)
+
+	VerifyChannelBackupsFunc func(context.Context, []byte) error
+
+	DecodePaymentRequestFunc func(context.Context,
+		string) (*lndclient.PaymentRequest, error)
+
+	OpenChannelFunc func(context.Context, route.Vertex, btcutil.Amount,
+		btcutil.Amount,
+		...lndclient.OpenChannelOption) (*wire.OutPoint, error)
+
+	CloseChannelFunc func(context.Context, *wire.OutPoint, bool,
+		...lndclient.CloseChannelOption) (
+		chan lndclient.CloseChannelUpdate, chan error, error)
+
+	RegisterFundingShimFunc func(context.Context,
+		lndclient.ChanPointShim) error
+
+	CancelFundingShimFunc func(context.Context, [32]byte) error
+
+	ConnectFunc func(context.Context, route.Vertex, string,
+		...lndclient.ConnectOption) (lndclient.ConnectStatus, error)
+
+	SubscribeInvoicesFunc func(context.Context,
+		lndclient.InvoiceSubscription) (<-chan *lndclient.Invoice,
+		<-chan error, error)
+
+	SubscribeChannelEventsFunc func(context.Context) (
+		<-chan *lndclient.ChannelEventUpdate, <-chan error, error)
+
+	SubscribeTransactionsFunc func(context.Context) (
+		<-chan lndclient.Transaction, <-chan error, error)
+
+	ListPeersFunc func(context.Context) ([]lndclient.Peer, error)
+
+	DescribeGraphFunc func(context.Context, bool) (*lndclient.Graph, error)
+
+	SubscribeGraphFunc func(context.Context) (
+		<-chan *lndclient.GraphTopologyUpdate, <-chan error, error)
+
+	GetChanInfoFunc func(context.Context,
+		uint64) (*lndclient.ChannelEdge, error)
+
+	GetNodeInfoFunc func(context.Context, route.Vertex,
+		bool) (*lndclient.NodeInfo, error)
+
+	QueryRoutesFunc func(context.Context,
+		lndclient.QueryRoutesRequest) (*lndclient.QueryRoutesResponse,
+		error)
+
+	FeeReportFunc func(context.Context) (*lndclient.FeeReport, error)
+
+	NewAddressFunc func(context.Context,
+		lnwallet.AddressType) (btcutil.Address, error)
+
+	SendCoinsFunc func(context.Context,
+		lndclient.SendCoinsRequest) (string, error)
+
+	RestoreChannelBackupsFunc func(context.Context, []byte) error
+
+	RestoreSingleChannelBackupsFunc func(context.Context,
+		[]lndclient.SingleChannelBackup) error
+
+	StopDaemonFunc func(context.Context) error
+
+	GetRecoveryInfoFunc func(context.Context) (*lndclient.RecoveryInfo,
+		error)
+}
+
+// A compile-time constraint to ensure LightningClient satisfies the
+// lndclient.LightningClient interface.
+var _ lndclient.LightningClient = (*LightningClient)(nil)
+
+// PayInvoice is part of the lndclient.LightningClient interface.
+func (m *LightningClient) PayInvoice(ctx context.Context, invoice string,
+	maxFee btcutil.Amount,
+	outgoingChannel *uint64) chan lndclient.PaymentResult {
+
+	if m.PayInvoiceFunc == nil {
+		result := make(chan lndclient.PaymentResult, 1)
+		result <- lndclient.PaymentResult{
+			Err: ErrNotImplemented,
+		}
+
+		return result
+	}
+
+	return m.PayInvoiceFunc(ctx, invoice, maxFee, outgoingChannel)
+}
+
+// PayInvoiceWithUpdates is part of the lndclient.LightningClient interface.
+func (m *LightningClient) PayInvoiceWithUpdates(ctx context.Context,
+	invoice string, maxFee btcutil.Amount, outgoingChannel *uint64) (
+	chan lndclient.PaymentUpdate, chan lndclient.PaymentResult) {
+
+	if m.PayInvoiceWithUpdatesFunc == nil {
+		updates := make(chan lndclient.PaymentUpdate)
+		close(updates)
+
+		result := make(chan lndclient.PaymentResult, 1)
+		result <- lndclient.PaymentResult{
+			Err: ErrNotImplemented,
+		}
+
+		return updates, result
+	}
+
+	return m.PayInvoiceWithUpdatesFunc(
+		ctx, invoice, maxFee, outgoingChannel,
+	)
+}
+
+// GetInfo is part of the lndclient.LightningClient interface.
+func (m *LightningClient) GetInfo(ctx context.Context) (*lndclient.Info,
+	error) {
+
+	if m.GetInfoFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.GetInfoFunc(ctx)
+}
+
+// EstimateFeeToP2WSH is part of the lndclient.LightningClient interface.
+func (m *LightningClient) EstimateFeeToP2WSH(ctx context.Context,
+	amt btcutil.Amount, confTarget int32) (btcutil.Amount, error) {
+
+	if m.EstimateFeeToP2WSHFunc == nil {
+		return 0, ErrNotImplemented
+	}
+
+	return m.EstimateFeeToP2WSHFunc(ctx, amt, confTarget)
+}
+
+// EstimateFee is part of the lndclient.LightningClient interface.
+func (m *LightningClient) EstimateFee(ctx context.Context,
+	outputs map[string]btcutil.Amount,
+	confTarget int32) (btcutil.Amount, chainfee.SatPerKVByte, error) {
+
+	if m.EstimateFeeFunc == nil {
+		return 0, 0, ErrNotImplemented
+	}
+
+	return m.EstimateFeeFunc(ctx, outputs, confTarget)
+}
+
+// ConfirmedWalletBalance is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ConfirmedWalletBalance(ctx context.Context) (
+	btcutil.Amount, error) {
+
+	if m.ConfirmedWalletBalanceFunc == nil {
+		return 0, ErrNotImplemented
+	}
+
+	return m.ConfirmedWalletBalanceFunc(ctx)
+}
+
+// WalletBalance is part of the lndclient.LightningClient interface.
+func (m *LightningClient) WalletBalance(ctx context.Context) (
+	*lndclient.WalletBalance, error) {
+
+	if m.WalletBalanceFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.WalletBalanceFunc(ctx)
+}
+
+// ChannelBalance is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ChannelBalance(ctx context.Context) (
+	*lndclient.ChannelBalance, error) {
+
+	if m.ChannelBalanceFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ChannelBalanceFunc(ctx)
+}
+
+// AddInvoice is part of the lndclient.LightningClient interface.
+func (m *LightningClient) AddInvoice(ctx context.Context,
+	in *invoicesrpc.AddInvoiceData, opts ...lndclient.AddInvoiceOption) (
+	lntypes.Hash, string, error) {
+
+	if m.AddInvoiceFunc == nil {
+		return lntypes.Hash{}, "", ErrNotImplemented
+	}
+
+	return m.AddInvoiceFunc(ctx, in, opts...)
+}
+
+// LookupInvoice is part of the lndclient.LightningClient interface.
+func (m *LightningClient) LookupInvoice(ctx context.Context,
+	hash lntypes.Hash) (*lndclient.Invoice, error) {
+
+	if m.LookupInvoiceFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.LookupInvoiceFunc(ctx, hash)
+}
+
+// ListTransactions is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ListTransactions(ctx context.Context,
+	startHeight int32, endHeight int32) ([]lndclient.Transaction, error) {
+
+	if m.ListTransactionsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListTransactionsFunc(ctx, startHeight, endHeight)
+}
+
+// ListChannels is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ListChannels(ctx context.Context,
+	opts ...lndclient.ListChannelsOption) ([]lndclient.ChannelInfo, error) {
+
+	if m.ListChannelsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListChannelsFunc(ctx, opts...)
+}
+
+// PendingChannels is part of the lndclient.LightningClient interface.
+func (m *LightningClient) PendingChannels(ctx context.Context) (
+	*lndclient.PendingChannels, error) {
+
+	if m.PendingChannelsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.PendingChannelsFunc(ctx)
+}
+
+// ClosedChannels is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ClosedChannels(ctx context.Context,
+	closeTypes ...lndclient.CloseType) ([]lndclient.ClosedChannel, error) {
+
+	if m.ClosedChannelsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ClosedChannelsFunc(ctx, closeTypes...)
+}
+
+// ForwardingHistory is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ForwardingHistory(ctx context.Context,
+	req lndclient.ForwardingHistoryRequest) (
+	*lndclient.ForwardingHistoryResponse, error) {
+
+	if m.ForwardingHistoryFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ForwardingHistoryFunc(ctx, req)
+}
+
+// ListInvoices is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ListInvoices(ctx context.Context,
+	req lndclient.ListInvoicesRequest) (*lndclient.ListInvoicesResponse,
+	error) {
+
+	if m.ListInvoicesFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListInvoicesFunc(ctx, req)
+}
+
+// ListPayments is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ListPayments(ctx context.Context,
+	req lndclient.ListPaymentsRequest) (*lndclient.ListPaymentsResponse,
+	error) {
+
+	if m.ListPaymentsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListPaymentsFunc(ctx, req)
+}
+
+// ChannelBackup is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ChannelBackup(ctx context.Context,
+	chanPoint wire.OutPoint) ([]byte, error) {
+
+	if m.ChannelBackupFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ChannelBackupFunc(ctx, chanPoint)
+}
+
+// ChannelBackups is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ChannelBackups(ctx context.Context) ([]byte, error) {
+	if m.ChannelBackupsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ChannelBackupsFunc(ctx)
+}
+
+// SubscribeChannelBackups is part of the lndclient.LightningClient interface.
+func (m *LightningClient) SubscribeChannelBackups(ctx context.Context) (
+	<-chan []byte, <-chan error, error) {
+
+	if m.SubscribeChannelBackupsFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.SubscribeChannelBackupsFunc(ctx)
+}
+
+// VerifyChannelBackups is part of the lndclient.LightningClient interface.
+func (m *LightningClient) VerifyChannelBackups(ctx context.Context,
+	multiBackup []byte) error {
+
+	if m.VerifyChannelBackupsFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.VerifyChannelBackupsFunc(ctx, multiBackup)
+}
+
+// DecodePaymentRequest is part of the lndclient.LightningClient interface.
+func (m *LightningClient) DecodePaymentRequest(ctx context.Context,
+	payReq string) (*lndclient.PaymentRequest, error) {
+
+	if m.DecodePaymentRequestFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.DecodePaymentRequestFunc(ctx, payReq)
+}
+
+// OpenChannel is part of the lndclient.LightningClient interface.
+func (m *LightningClient) OpenChannel(ctx context.Context, peer route.Vertex,
+	localSat btcutil.Amount, pushSat btcutil.Amount,
+	opts ...lndclient.OpenChannelOption) (*wire.OutPoint, error) {
+
+	if m.OpenChannelFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.OpenChannelFunc(ctx, peer, localSat, pushSat, opts...)
+}
+
+// CloseChannel is part of the lndclient.LightningClient interface.
+func (m *LightningClient) CloseChannel(ctx context.Context,
+	channel *wire.OutPoint, force bool,
+	opts ...lndclient.CloseChannelOption) (
+	chan lndclient.CloseChannelUpdate, chan error, error) {
+
+	if m.CloseChannelFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.CloseChannelFunc(ctx, channel, force, opts...)
+}
+
+// RegisterFundingShim is part of the lndclient.LightningClient interface.
+func (m *LightningClient) RegisterFundingShim(ctx context.Context,
+	shim lndclient.ChanPointShim) error {
+
+	if m.RegisterFundingShimFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.RegisterFundingShimFunc(ctx, shim)
+}
+
+// CancelFundingShim is part of the lndclient.LightningClient interface.
+func (m *LightningClient) CancelFundingShim(ctx context.Context,
+	pendingChanID [32]byte) error {
+
+	if m.CancelFundingShimFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.CancelFundingShimFunc(ctx, pendingChanID)
+}
+
+// Connect is part of the lndclient.LightningClient interface.
+func (m *LightningClient) Connect(ctx context.Context, peer route.Vertex,
+	host string, opts ...lndclient.ConnectOption) (lndclient.ConnectStatus,
+	error) {
+
+	if m.ConnectFunc == nil {
+		return 0, ErrNotImplemented
+	}
+
+	return m.ConnectFunc(ctx, peer, host, opts...)
+}
+
+// SubscribeInvoices is part of the lndclient.LightningClient interface.
+func (m *LightningClient) SubscribeInvoices(ctx context.Context,
+	req lndclient.InvoiceSubscription) (<-chan *lndclient.Invoice,
+	<-chan error, error) {
+
+	if m.SubscribeInvoicesFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.SubscribeInvoicesFunc(ctx, req)
+}
+
+// SubscribeChannelEvents is part of the lndclient.LightningClient interface.
+func (m *LightningClient) SubscribeChannelEvents(ctx context.Context) (
+	<-chan *lndclient.ChannelEventUpdate, <-chan error, error) {
+
+	if m.SubscribeChannelEventsFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.SubscribeChannelEventsFunc(ctx)
+}
+
+// SubscribeTransactions is part of the lndclient.LightningClient interface.
+func (m *LightningClient) SubscribeTransactions(ctx context.Context) (
+	<-chan lndclient.Transaction, <-chan error, error) {
+
+	if m.SubscribeTransactionsFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.SubscribeTransactionsFunc(ctx)
+}
+
+// ListPeers is part of the lndclient.LightningClient interface.
+func (m *LightningClient) ListPeers(ctx context.Context) ([]lndclient.Peer,
+	error) {
+
+	if m.ListPeersFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListPeersFunc(ctx)
+}
+
+// DescribeGraph is part of the lndclient.LightningClient interface.
+func (m *LightningClient) DescribeGraph(ctx context.Context,
+	includeUnannounced bool) (*lndclient.Graph, error) {
+
+	if m.DescribeGraphFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.DescribeGraphFunc(ctx, includeUnannounced)
+}
+
+// SubscribeGraph is part of the lndclient.LightningClient interface.
+func (m *LightningClient) SubscribeGraph(ctx context.Context) (
+	<-chan *lndclient.GraphTopologyUpdate, <-chan error, error) {
+
+	if m.SubscribeGraphFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.SubscribeGraphFunc(ctx)
+}
+
+// GetChanInfo is part of the lndclient.LightningClient interface.
+func (m *LightningClient) GetChanInfo(ctx context.Context,
+	chanID uint64) (*lndclient.ChannelEdge, error) {
+
+	if m.GetChanInfoFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.GetChanInfoFunc(ctx, chanID)
+}
+
+// GetNodeInfo is part of the lndclient.LightningClient interface.
+func (m *LightningClient) GetNodeInfo(ctx context.Context, pubkey route.Vertex,
+	includeChannels bool) (*lndclient.NodeInfo, error) {
+
+	if m.GetNodeInfoFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.GetNodeInfoFunc(ctx, pubkey, includeChannels)
+}
+
+// QueryRoutes is part of the lndclient.LightningClient interface.
+func (m *LightningClient) QueryRoutes(ctx context.Context,
+	req lndclient.QueryRoutesRequest) (*lndclient.QueryRoutesResponse,
+	error) {
+
+	if m.QueryRoutesFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.QueryRoutesFunc(ctx, req)
+}
+
+// FeeReport is part of the lndclient.LightningClient interface.
+func (m *LightningClient) FeeReport(ctx context.Context) (*lndclient.FeeReport,
+	error) {
+
+	if m.FeeReportFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.FeeReportFunc(ctx)
+}
+
+// NewAddress is part of the lndclient.LightningClient interface.
+func (m *LightningClient) NewAddress(ctx context.Context,
+	addrType lnwallet.AddressType) (btcutil.Address, error) {
+
+	if m.NewAddressFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.NewAddressFunc(ctx, addrType)
+}
+
+// SendCoins is part of the lndclient.LightningClient interface.
+func (m *LightningClient) SendCoins(ctx context.Context,
+	req lndclient.SendCoinsRequest) (string, error) {
+
+	if m.SendCoinsFunc == nil {
+		return "", ErrNotImplemented
+	}
+
+	return m.SendCoinsFunc(ctx, req)
+}
+
+// RestoreChannelBackups is part of the lndclient.LightningClient interface.
+func (m *LightningClient) RestoreChannelBackups(ctx context.Context,
+	multiBackup []byte) error {
+
+	if m.RestoreChannelBackupsFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.RestoreChannelBackupsFunc(ctx, multiBackup)
+}
+
+// RestoreSingleChannelBackups is part of the lndclient.LightningClient
+// interface.
+func (m *LightningClient) RestoreSingleChannelBackups(ctx context.Context,
+	backups []lndclient.SingleChannelBackup) error {
+
+	if m.RestoreSingleChannelBackupsFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.RestoreSingleChannelBackupsFunc(ctx, backups)
+}
+
+// StopDaemon is part of the lndclient.LightningClient interface.
+func (m *LightningClient) StopDaemon(ctx context.Context) error {
+	if m.StopDaemonFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.StopDaemonFunc(ctx)
+}
+
+// GetRecoveryInfo is part of the lndclient.LightningClient interface.
+func (m *LightningClient) GetRecoveryInfo(ctx context.Context) (
+	*lndclient.RecoveryInfo, error) {
+
+	if m.GetRecoveryInfoFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.GetRecoveryInfoFunc(ctx)
+}
@@ -0,0 +1,67 @@
+// Package mock contains configurable mock implementations of all lndclient
+// service interfaces, for use in the unit tests of applications that build on
+// lndclient.
+//
+// Every mock has one function field per interface method. Tests set the
+// fields they need to return canned responses, inject errors or drive
+// streams through channels they control. Methods without a function return
+// ErrNotImplemented, so unexpected calls surface as test failures instead of
+// nil pointer panics.
+package mock
+
+import (
+	"errors"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/lndclient"
+)
+
+// ErrNotImplemented is returned by every mock method that has no function
+// configured.
+var ErrNotImplemented = errors.New("mock method not implemented")
+
+// Services holds a mock for every lndclient service.
+type Services struct {
+	Client        *LightningClient
+	WalletKit     *WalletKitClient
+	ChainNotifier *ChainNotifierClient
+	Signer        *SignerClient
+	Invoices      *InvoicesClient
+	Router        *RouterClient
+	Versioner     *VersionerClient
+	WtClient      *WatchtowerClient
+	Watchtower    *WatchtowerServerClient
+}
+
+// NewServices returns a set of mocks without any configured functions.
+func NewServices() *Services {
+	return &Services{
+		Client:        &LightningClient{},
+		WalletKit:     &WalletKitClient{},
+		ChainNotifier: &ChainNotifierClient{},
+		Signer:        &SignerClient{},
+		Invoices:      &InvoicesClient{},
+		Router:        &RouterClient{},
+		Versioner:     &VersionerClient{},
+		WtClient:      &WatchtowerClient{},
+		Watchtower:    &WatchtowerServerClient{},
+	}
+}
+
+// LndServices returns an lndclient.LndServices that is backed by the mocks,
+// so it can be passed to code under test in place of a real connection. The
+// chain params are set to regtest.
+func (s *Services) LndServices() *lndclient.LndServices {
+	return &lndclient.LndServices{
+		Client:        s.Client,
+		WalletKit:     s.WalletKit,
+		ChainNotifier: s.ChainNotifier,
+		Signer:        s.Signer,
+		Invoices:      s.Invoices,
+		Router:        s.Router,
+		Versioner:     s.Versioner,
+		WtClient:      s.WtClient,
+		Watchtower:    s.Watchtower,
+		ChainParams:   &chaincfg.RegressionNetParams,
+	}
+}
@@ -0,0 +1,147 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// RouterClient is a configurable mock implementation of the
+// lndclient.RouterClient interface. Every method calls the function field of
+// the same name with a Func suffix. If that field is nil, the method returns
+// ErrNotImplemented.
+type RouterClient struct {
+	SendPaymentFunc func(context.Context,
+		lndclient.SendPaymentRequest) (chan lndclient.PaymentStatus,
+		chan error, error)
+
+	TrackPaymentFunc func(context.Context,
+		lntypes.Hash) (chan lndclient.PaymentStatus, chan error, error)
+
+	BuildRouteFunc func(context.Context, *lnwire.MilliSatoshi, int32,
+		*uint64, []route.Vertex) (*lndclient.Route, error)
+
+	SendToRouteFunc func(context.Context, lntypes.Hash,
+		*lndclient.Route) (*lndclient.HtlcAttempt, error)
+
+	ResetMissionControlFunc func(context.Context) error
+
+	QueryMissionControlFunc func(context.Context) ([]lndclient.PairHistory,
+		error)
+
+	QueryProbabilityFunc func(context.Context, route.Vertex, route.Vertex,
+		lnwire.MilliSatoshi) (float64, *lndclient.PairData, error)
+
+	InterceptHtlcsFunc func(context.Context,
+		lndclient.HtlcInterceptHandler) error
+
+	SubscribeHtlcEventsFunc func(context.Context) (
+		<-chan *lndclient.HtlcEvent, <-chan error, error)
+}
+
+// A compile-time constraint to ensure RouterClient satisfies the
+// lndclient.RouterClient interface.
+var _ lndclient.RouterClient = (*RouterClient)(nil)
+
+// SendPayment is part of the lndclient.RouterClient interface.
+func (m *RouterClient) SendPayment(ctx context.Context,
+	request lndclient.SendPaymentRequest) (chan lndclient.PaymentStatus,
+	chan error, error) {
+
+	if m.SendPaymentFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.SendPaymentFunc(ctx, request)
+}
+
+// TrackPayment is part of the lndclient.RouterClient interface.
+func (m *RouterClient) TrackPayment(ctx context.Context,
+	hash lntypes.Hash) (chan lndclient.PaymentStatus, chan error, error) {
+
+	if m.TrackPaymentFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.TrackPaymentFunc(ctx, hash)
+}
+
+// BuildRoute is part of the lndclient.RouterClient interface.
+func (m *RouterClient) BuildRoute(ctx context.Context, amt *lnwire.MilliSatoshi,
+	finalCltvDelta int32, outgoingChannel *uint64,
+	hops []route.Vertex) (*lndclient.Route, error) {
+
+	if m.BuildRouteFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.BuildRouteFunc(ctx, amt, finalCltvDelta, outgoingChannel, hops)
+}
+
+// SendToRoute is part of the lndclient.RouterClient interface.
+func (m *RouterClient) SendToRoute(ctx context.Context, hash lntypes.Hash,
+	route *lndclient.Route) (*lndclient.HtlcAttempt, error) {
+
+	if m.SendToRouteFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.SendToRouteFunc(ctx, hash, route)
+}
+
+// ResetMissionControl is part of the lndclient.RouterClient interface.
+func (m *RouterClient) ResetMissionControl(ctx context.Context) error {
+	if m.ResetMissionControlFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.ResetMissionControlFunc(ctx)
+}
+
+// QueryMissionControl is part of the lndclient.RouterClient interface.
+func (m *RouterClient) QueryMissionControl(ctx context.Context) (
+	[]lndclient.PairHistory, error) {
+
+	if m.QueryMissionControlFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.QueryMissionControlFunc(ctx)
+}
+
+// QueryProbability is part of the lndclient.RouterClient interface.
+func (m *RouterClient) QueryProbability(ctx context.Context,
+	fromNode route.Vertex, toNode route.Vertex,
+	amt lnwire.MilliSatoshi) (float64, *lndclient.PairData, error) {
+
+	if m.QueryProbabilityFunc == nil {
+		return 0, nil, ErrNotImplemented
+	}
+
+	return m.QueryProbabilityFunc(ctx, fromNode, toNode, amt)
+}
+
+// InterceptHtlcs is part of the lndclient.RouterClient interface.
+func (m *RouterClient) InterceptHtlcs(ctx context.Context,
+	handler lndclient.HtlcInterceptHandler) error {
+
+	if m.InterceptHtlcsFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.InterceptHtlcsFunc(ctx, handler)
+}
+
+// SubscribeHtlcEvents is part of the lndclient.RouterClient interface.
+func (m *RouterClient) SubscribeHtlcEvents(ctx context.Context) (
+	<-chan *lndclient.HtlcEvent, <-chan error, error) {
+
+	if m.SubscribeHtlcEventsFunc == nil {
+		return nil, nil, ErrNotImplemented
+	}
+
+	return m.SubscribeHtlcEventsFunc(ctx)
+}
@@ -0,0 +1,92 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+// SignerClient is a configurable mock implementation of the
+// lndclient.SignerClient interface. Every method calls the function field of
+// the same name with a Func suffix. If that field is nil, the method returns
+// ErrNotImplemented.
+type SignerClient struct {
+	SignOutputRawFunc func(context.Context, *wire.MsgTx,
+		[]*lndclient.SignDescriptor) ([][]byte, error)
+
+	ComputeInputScriptFunc func(context.Context, *wire.MsgTx,
+		[]*lndclient.SignDescriptor) ([]*input.Script, error)
+
+	SignMessageFunc func(context.Context, []byte,
+		keychain.KeyLocator) ([]byte, error)
+
+	VerifyMessageFunc func(context.Context, []byte, []byte,
+		[33]byte) (bool, error)
+
+	DeriveSharedKeyFunc func(context.Context, *btcec.PublicKey,
+		*keychain.KeyLocator) ([32]byte, error)
+}
+
+// A compile-time constraint to ensure SignerClient satisfies the
+// lndclient.SignerClient interface.
+var _ lndclient.SignerClient = (*SignerClient)(nil)
+
+// SignOutputRaw is part of the lndclient.SignerClient interface.
+func (m *SignerClient) SignOutputRaw(ctx context.Context, tx *wire.MsgTx,
+	signDescriptors []*lndclient.SignDescriptor) ([][]byte, error) {
+
+	if m.SignOutputRawFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.SignOutputRawFunc(ctx, tx, signDescriptors)
+}
+
+// ComputeInputScript is part of the lndclient.SignerClient interface.
+func (m *SignerClient) ComputeInputScript(ctx context.Context, tx *wire.MsgTx,
+	signDescriptors []*lndclient.SignDescriptor) ([]*input.Script, error) {
+
+	if m.ComputeInputScriptFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ComputeInputScriptFunc(ctx, tx, signDescriptors)
+}
+
+// SignMessage is part of the lndclient.SignerClient interface.
+func (m *SignerClient) SignMessage(ctx context.Context, msg []byte,
+	locator keychain.KeyLocator) ([]byte, error) {
+
+	if m.SignMessageFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.SignMessageFunc(ctx, msg, locator)
+}
+
+// VerifyMessage is part of the lndclient.SignerClient interface.
+func (m *SignerClient) VerifyMessage(ctx context.Context, msg []byte,
+	sig []byte, pubkey [33]byte) (bool, error) {
+
+	if m.VerifyMessageFunc == nil {
+		return false, ErrNotImplemented
+	}
+
+	return m.VerifyMessageFunc(ctx, msg, sig, pubkey)
+}
+
+// DeriveSharedKey is part of the lndclient.SignerClient interface.
+func (m *SignerClient) DeriveSharedKey(ctx context.Context,
+	ephemeralPubKey *btcec.PublicKey,
+	keyLocator *keychain.KeyLocator) ([32]byte, error) {
+
+	if m.DeriveSharedKeyFunc == nil {
+		return [32]byte{}, ErrNotImplemented
+	}
+
+	return m.DeriveSharedKeyFunc(ctx, ephemeralPubKey, keyLocator)
+}
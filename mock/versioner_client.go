@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc/verrpc"
+)
+
+// VersionerClient is a configurable mock implementation of the
+// lndclient.VersionerClient interface. Every method calls the function field of
+// the same name with a Func suffix. If that field is nil, the method returns
+// ErrNotImplemented.
+type VersionerClient struct {
+	GetVersionFunc func(context.Context) (*verrpc.Version, error)
+}
+
+// A compile-time constraint to ensure VersionerClient satisfies the
+// lndclient.VersionerClient interface.
+var _ lndclient.VersionerClient = (*VersionerClient)(nil)
+
+// GetVersion is part of the lndclient.VersionerClient interface.
+func (m *VersionerClient) GetVersion(ctx context.Context) (*verrpc.Version,
+	error) {
+
+	if m.GetVersionFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.GetVersionFunc(ctx)
+}
@@ -0,0 +1,203 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+// WalletKitClient is a configurable mock implementation of the
+// lndclient.WalletKitClient interface. Every method calls the function field of
+// the same name with a Func suffix. If that field is nil, the method returns
+// ErrNotImplemented.
+type WalletKitClient struct {
+	ListUnspentFunc func(context.Context, int32,
+		int32) ([]*lnwallet.Utxo, error)
+
+	LeaseOutputFunc func(context.Context, wtxmgr.LockID,
+		wire.OutPoint) (time.Time, error)
+
+	ReleaseOutputFunc func(context.Context, wtxmgr.LockID,
+		wire.OutPoint) error
+
+	DeriveNextKeyFunc func(context.Context,
+		int32) (*keychain.KeyDescriptor, error)
+
+	DeriveKeyFunc func(context.Context,
+		*keychain.KeyLocator) (*keychain.KeyDescriptor, error)
+
+	NextAddrFunc func(context.Context) (btcutil.Address, error)
+
+	PublishTransactionFunc func(context.Context, *wire.MsgTx, string) error
+
+	SendOutputsFunc func(context.Context, []*wire.TxOut,
+		chainfee.SatPerKWeight, string) (*wire.MsgTx, error)
+
+	EstimateFeeFunc func(context.Context,
+		int32) (chainfee.SatPerKWeight, error)
+
+	ListSweepsFunc func(context.Context) ([]string, error)
+
+	ListSweepsVerboseFunc func(context.Context) ([]lndclient.Transaction,
+		error)
+
+	BumpFeeFunc func(context.Context, wire.OutPoint,
+		...lndclient.BumpFeeOption) error
+
+	LabelTransactionFunc func(context.Context, chainhash.Hash, string,
+		bool) error
+}
+
+// A compile-time constraint to ensure WalletKitClient satisfies the
+// lndclient.WalletKitClient interface.
+var _ lndclient.WalletKitClient = (*WalletKitClient)(nil)
+
+// ListUnspent is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) ListUnspent(ctx context.Context, minConfs int32,
+	maxConfs int32) ([]*lnwallet.Utxo, error) {
+
+	if m.ListUnspentFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListUnspentFunc(ctx, minConfs, maxConfs)
+}
+
+// LeaseOutput is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) LeaseOutput(ctx context.Context, lockID wtxmgr.LockID,
+	op wire.OutPoint) (time.Time, error) {
+
+	if m.LeaseOutputFunc == nil {
+		return time.Time{}, ErrNotImplemented
+	}
+
+	return m.LeaseOutputFunc(ctx, lockID, op)
+}
+
+// ReleaseOutput is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) ReleaseOutput(ctx context.Context,
+	lockID wtxmgr.LockID, op wire.OutPoint) error {
+
+	if m.ReleaseOutputFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.ReleaseOutputFunc(ctx, lockID, op)
+}
+
+// DeriveNextKey is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) DeriveNextKey(ctx context.Context,
+	family int32) (*keychain.KeyDescriptor, error) {
+
+	if m.DeriveNextKeyFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.DeriveNextKeyFunc(ctx, family)
+}
+
+// DeriveKey is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) DeriveKey(ctx context.Context,
+	locator *keychain.KeyLocator) (*keychain.KeyDescriptor, error) {
+
+	if m.DeriveKeyFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.DeriveKeyFunc(ctx, locator)
+}
+
+// NextAddr is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) NextAddr(ctx context.Context) (btcutil.Address,
+	error) {
+
+	if m.NextAddrFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.NextAddrFunc(ctx)
+}
+
+// PublishTransaction is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) PublishTransaction(ctx context.Context,
+	tx *wire.MsgTx, label string) error {
+
+	if m.PublishTransactionFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.PublishTransactionFunc(ctx, tx, label)
+}
+
+// SendOutputs is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) SendOutputs(ctx context.Context,
+	outputs []*wire.TxOut, feeRate chainfee.SatPerKWeight,
+	label string) (*wire.MsgTx, error) {
+
+	if m.SendOutputsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.SendOutputsFunc(ctx, outputs, feeRate, label)
+}
+
+// EstimateFee is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) EstimateFee(ctx context.Context,
+	confTarget int32) (chainfee.SatPerKWeight, error) {
+
+	if m.EstimateFeeFunc == nil {
+		return 0, ErrNotImplemented
+	}
+
+	return m.EstimateFeeFunc(ctx, confTarget)
+}
+
+// ListSweeps is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) ListSweeps(ctx context.Context) ([]string, error) {
+	if m.ListSweepsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListSweepsFunc(ctx)
+}
+
+// ListSweepsVerbose is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) ListSweepsVerbose(ctx context.Context) (
+	[]lndclient.Transaction, error) {
+
+	if m.ListSweepsVerboseFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListSweepsVerboseFunc(ctx)
+}
+
+// BumpFee is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) BumpFee(ctx context.Context, outpoint wire.OutPoint,
+	opts ...lndclient.BumpFeeOption) error {
+
+	if m.BumpFeeFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.BumpFeeFunc(ctx, outpoint, opts...)
+}
+
+// LabelTransaction is part of the lndclient.WalletKitClient interface.
+func (m *WalletKitClient) LabelTransaction(ctx context.Context,
+	txid chainhash.Hash, label string, overwrite bool) error {
+
+	if m.LabelTransactionFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.LabelTransactionFunc(ctx, txid, label, overwrite)
+}
@@ -0,0 +1,97 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// WatchtowerClient is a configurable mock implementation of the
+// lndclient.WatchtowerClient interface. Every method calls the function field
+// of the same name with a Func suffix. If that field is nil, the method returns
+// ErrNotImplemented.
+type WatchtowerClient struct {
+	AddTowerFunc func(context.Context, route.Vertex, string) error
+
+	RemoveTowerFunc func(context.Context, route.Vertex, string) error
+
+	ListTowersFunc func(context.Context, bool) ([]lndclient.Tower, error)
+
+	GetTowerInfoFunc func(context.Context, route.Vertex,
+		bool) (*lndclient.Tower, error)
+
+	StatsFunc func(context.Context) (*lndclient.TowerClientStats, error)
+
+	PolicyFunc func(context.Context) (*lndclient.TowerPolicy, error)
+}
+
+// A compile-time constraint to ensure WatchtowerClient satisfies the
+// lndclient.WatchtowerClient interface.
+var _ lndclient.WatchtowerClient = (*WatchtowerClient)(nil)
+
+// AddTower is part of the lndclient.WatchtowerClient interface.
+func (m *WatchtowerClient) AddTower(ctx context.Context, pubkey route.Vertex,
+	address string) error {
+
+	if m.AddTowerFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.AddTowerFunc(ctx, pubkey, address)
+}
+
+// RemoveTower is part of the lndclient.WatchtowerClient interface.
+func (m *WatchtowerClient) RemoveTower(ctx context.Context, pubkey route.Vertex,
+	address string) error {
+
+	if m.RemoveTowerFunc == nil {
+		return ErrNotImplemented
+	}
+
+	return m.RemoveTowerFunc(ctx, pubkey, address)
+}
+
+// ListTowers is part of the lndclient.WatchtowerClient interface.
+func (m *WatchtowerClient) ListTowers(ctx context.Context,
+	includeSessions bool) ([]lndclient.Tower, error) {
+
+	if m.ListTowersFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.ListTowersFunc(ctx, includeSessions)
+}
+
+// GetTowerInfo is part of the lndclient.WatchtowerClient interface.
+func (m *WatchtowerClient) GetTowerInfo(ctx context.Context,
+	pubkey route.Vertex, includeSessions bool) (*lndclient.Tower, error) {
+
+	if m.GetTowerInfoFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.GetTowerInfoFunc(ctx, pubkey, includeSessions)
+}
+
+// Stats is part of the lndclient.WatchtowerClient interface.
+func (m *WatchtowerClient) Stats(ctx context.Context) (
+	*lndclient.TowerClientStats, error) {
+
+	if m.StatsFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.StatsFunc(ctx)
+}
+
+// Policy is part of the lndclient.WatchtowerClient interface.
+func (m *WatchtowerClient) Policy(ctx context.Context) (*lndclient.TowerPolicy,
+	error) {
+
+	if m.PolicyFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.PolicyFunc(ctx)
+}
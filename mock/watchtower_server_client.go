@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/lightninglabs/lndclient"
+)
+
+// WatchtowerServerClient is a configurable mock implementation of the
+// lndclient.WatchtowerServerClient interface. Every method calls the function
+// field of the same name with a Func suffix. If that field is nil, the method
+// returns ErrNotImplemented.
+type WatchtowerServerClient struct {
+	GetInfoFunc func(context.Context) (*lndclient.WatchtowerInfo, error)
+}
+
+// A compile-time constraint to ensure WatchtowerServerClient satisfies the
+// lndclient.WatchtowerServerClient interface.
+var _ lndclient.WatchtowerServerClient = (*WatchtowerServerClient)(nil)
+
+// GetInfo is part of the lndclient.WatchtowerServerClient interface.
+func (m *WatchtowerServerClient) GetInfo(ctx context.Context) (
+	*lndclient.WatchtowerInfo, error) {
+
+	if m.GetInfoFunc == nil {
+		return nil, ErrNotImplemented
+	}
+
+	return m.GetInfoFunc(ctx)
+}
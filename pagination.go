@@ -0,0 +1,141 @@
+package lndclient
+
+import (
+	"context"
+)
+
+const (
+	// defaultPageSize is the number of items that are queried per call by
+	// the pagination helpers if the request doesn't specify a maximum.
+	defaultPageSize = 100
+)
+
+// ForEachInvoice drives the pagination of ListInvoices and calls the given
+// function for every invoice, starting at the offset and in the direction set
+// in the request. The maximum number of invoices in the request is used as
+// page size. If the function returns an error, the iteration is aborted and
+// the error is returned.
+func ForEachInvoice(ctx context.Context, client LightningClient,
+	req ListInvoicesRequest, cb func(Invoice) error) error {
+
+	if req.MaxInvoices == 0 {
+		req.MaxInvoices = defaultPageSize
+	}
+
+	for {
+		resp, err := client.ListInvoices(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, invoice := range resp.Invoices {
+			if err := cb(invoice); err != nil {
+				return err
+			}
+		}
+
+		next := nextOffset(
+			req.Offset, resp.FirstIndexOffset, resp.LastIndexOffset,
+			req.Reversed, len(resp.Invoices),
+		)
+		if next == req.Offset {
+			return nil
+		}
+		req.Offset = next
+	}
+}
+
+// ForEachPayment drives the pagination of ListPayments and calls the given
+// function for every payment, starting at the offset and in the direction set
+// in the request. The maximum number of payments in the request is used as
+// page size. If the function returns an error, the iteration is aborted and
+// the error is returned.
+func ForEachPayment(ctx context.Context, client LightningClient,
+	req ListPaymentsRequest, cb func(Payment) error) error {
+
+	if req.MaxPayments == 0 {
+		req.MaxPayments = defaultPageSize
+	}
+
+	for {
+		resp, err := client.ListPayments(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, payment := range resp.Payments {
+			if err := cb(payment); err != nil {
+				return err
+			}
+		}
+
+		next := nextOffset(
+			req.Offset, resp.FirstIndexOffset, resp.LastIndexOffset,
+			req.Reversed, len(resp.Payments),
+		)
+		if next == req.Offset {
+			return nil
+		}
+		req.Offset = next
+	}
+}
+
+// ForEachForwardingEvent drives the pagination of ForwardingHistory and calls
+// the given function for every forwarding event in the queried period,
+// starting at the offset set in the request. The maximum number of events in
+// the request is used as page size. If the function returns an error, the
+// iteration is aborted and the error is returned.
+func ForEachForwardingEvent(ctx context.Context, client LightningClient,
+	req ForwardingHistoryRequest, cb func(ForwardingEvent) error) error {
+
+	if req.MaxEvents == 0 {
+		req.MaxEvents = defaultPageSize
+	}
+
+	for {
+		resp, err := client.ForwardingHistory(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range resp.Events {
+			if err := cb(event); err != nil {
+				return err
+			}
+		}
+
+		next := nextOffset(
+			uint64(req.Offset), 0, uint64(resp.LastIndexOffset),
+			false, len(resp.Events),
+		)
+		if next == uint64(req.Offset) {
+			return nil
+		}
+		req.Offset = uint32(next)
+	}
+}
+
+// nextOffset returns the offset to query the page following a response with
+// the given index offsets. If there are no more pages, the current offset is
+// returned.
+func nextOffset(current, first, last uint64, reversed bool,
+	numItems int) uint64 {
+
+	// An empty page means that we've reached the end of the list in the
+	// direction we're querying.
+	if numItems == 0 {
+		return current
+	}
+
+	// Items with index 1 are the first ones in the database, so there is
+	// nothing left when querying backwards.
+	if reversed {
+		if first <= 1 {
+			return current
+		}
+
+		return first
+	}
+
+	return last
+}
@@ -0,0 +1,124 @@
+package lndclient
+
+import (
+	"context"
+	"testing"
+)
+
+// pagedInvoiceClient is a lightning client that serves a fixed list of
+// invoices through ListInvoices, imitating lnd's offset pagination.
+type pagedInvoiceClient struct {
+	LightningClient
+
+	invoices []Invoice
+}
+
+// ListInvoices returns the invoices with an index after the offset, or before
+// the offset if the request is reversed. The index of an invoice is its
+// position in the list, starting at 1.
+func (c *pagedInvoiceClient) ListInvoices(_ context.Context,
+	req ListInvoicesRequest) (*ListInvoicesResponse, error) {
+
+	var first, last uint64
+	var page []Invoice
+	for i := range c.invoices {
+		index := uint64(i + 1)
+		if req.Reversed {
+			index = uint64(len(c.invoices) - i)
+		}
+
+		switch {
+		case uint64(len(page)) == req.MaxInvoices:
+			continue
+
+		case !req.Reversed && index <= req.Offset:
+			continue
+
+		case req.Reversed && req.Offset != 0 && index >= req.Offset:
+			continue
+		}
+
+		if first == 0 || index < first {
+			first = index
+		}
+		if index > last {
+			last = index
+		}
+		page = append(page, c.invoices[index-1])
+	}
+
+	// Like lnd, we always return the invoices of a page in ascending
+	// order, even if the query is reversed.
+	if req.Reversed {
+		for i, j := 0, len(page)-1; i < j; i, j = i+1, j-1 {
+			page[i], page[j] = page[j], page[i]
+		}
+	}
+
+	return &ListInvoicesResponse{
+		FirstIndexOffset: first,
+		LastIndexOffset:  last,
+		Invoices:         page,
+	}, nil
+}
+
+// TestForEachInvoice makes sure every invoice is visited exactly once, in
+// both directions and for page sizes that do and don't divide the number of
+// invoices.
+func TestForEachInvoice(t *testing.T) {
+	invoices := make([]Invoice, 5)
+	for i := range invoices {
+		invoices[i].Memo = string(rune('a' + i))
+	}
+
+	testCases := []struct {
+		name     string
+		req      ListInvoicesRequest
+		expected string
+	}{{
+		name:     "forward",
+		req:      ListInvoicesRequest{MaxInvoices: 2},
+		expected: "abcde",
+	}, {
+		name:     "forward with offset",
+		req:      ListInvoicesRequest{MaxInvoices: 2, Offset: 2},
+		expected: "cde",
+	}, {
+		name:     "reversed",
+		req:      ListInvoicesRequest{MaxInvoices: 2, Reversed: true},
+		expected: "debca",
+	}, {
+		name:     "single page",
+		req:      ListInvoicesRequest{MaxInvoices: 5},
+		expected: "abcde",
+	}, {
+		name:     "default page size",
+		req:      ListInvoicesRequest{},
+		expected: "abcde",
+	}}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &pagedInvoiceClient{invoices: invoices}
+
+			var visited string
+			err := ForEachInvoice(
+				context.Background(), client, testCase.req,
+				func(invoice Invoice) error {
+					visited += invoice.Memo
+					return nil
+				},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if visited != testCase.expected {
+				t.Fatalf("expected %v, got %v",
+					testCase.expected, visited)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package lndclient
+
+import (
+	"container/heap"
+	"errors"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// defaultRiskFactor is the default weight of the time value of the
+	// locked funds, expressed as a fraction of the amount per block. It
+	// matches the default of lnd's pathfinding.
+	defaultRiskFactor = 15e-9
+
+	// defaultMaxHops is the maximum number of hops of a route, which is
+	// limited by the size of the onion packet.
+	defaultMaxHops = 20
+)
+
+// ErrNoPathFound is returned if there is no path between the source and the
+// target that can carry the amount.
+var ErrNoPathFound = errors.New("no path found")
+
+// PathRequest contains the parameters of a local path search.
+type PathRequest struct {
+	// Source is the node the route starts at, usually our own node. The
+	// source doesn't charge fees for the first hop.
+	Source route.Vertex
+
+	// Target is the destination of the route.
+	Target route.Vertex
+
+	// Amount is the amount the target should receive.
+	Amount lnwire.MilliSatoshi
+
+	// FinalCltvDelta is the cltv delta required by the target.
+	FinalCltvDelta uint32
+
+	// CurrentHeight is the current block height, used to compute the
+	// absolute expiries of the route.
+	CurrentHeight uint32
+
+	// RiskFactor weighs the time the amount is locked up against the fees.
+	// It is expressed as a fraction of the amount per block of cltv delta.
+	// If zero, lnd's default is used.
+	RiskFactor float64
+
+	// MaxHops is the maximum number of hops of the route. If zero, a
+	// default of 20 is used.
+	MaxHops int
+
+	// IgnoredNodes is a set of nodes that the route must not go through.
+	IgnoredNodes map[route.Vertex]struct{}
+
+	// IgnoredChannels is a set of channels that the route must not use.
+	IgnoredChannels map[uint64]struct{}
+}
+
+// pathNode holds the state of a node in the path search. All values are
+// those of the route from the node to the target.
+type pathNode struct {
+	vertex route.Vertex
+
+	// amt is the amount that needs to arrive at the node.
+	amt lnwire.MilliSatoshi
+
+	// weight is the combined fee and time lock weight of the route.
+	weight float64
+
+	// hops is the number of hops of the route.
+	hops int
+
+	// chanID and next are the channel and the node the route continues
+	// with, unset for the target.
+	chanID uint64
+	next   route.Vertex
+
+	// index is the position of the node in the heap.
+	index int
+}
+
+// pathHeap is a min heap of path nodes ordered by weight.
+type pathHeap []*pathNode
+
+func (h pathHeap) Len() int           { return len(h) }
+func (h pathHeap) Less(i, j int) bool { return h[i].weight < h[j].weight }
+
+func (h pathHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *pathHeap) Push(x interface{}) {
+	node := x.(*pathNode)
+	node.index = len(*h)
+	*h = append(*h, node)
+}
+
+func (h *pathHeap) Pop() interface{} {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+	node.index = -1
+	return node
+}
+
+// FindRoute computes the route with the lowest combined fee and time lock
+// weight from the source to the target over the cached graph, without a
+// round trip to lnd. Like lnd, the search runs backwards from the target, so
+// that the fees of every hop are computed for the exact amount it forwards.
+func (g *GraphCache) FindRoute(req PathRequest) (*Route, error) {
+	riskFactor := req.RiskFactor
+	if riskFactor == 0 {
+		riskFactor = defaultRiskFactor
+	}
+
+	maxHops := req.MaxHops
+	if maxHops == 0 {
+		maxHops = defaultMaxHops
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	target := &pathNode{
+		vertex: req.Target,
+		amt:    req.Amount,
+	}
+	nodes := map[route.Vertex]*pathNode{req.Target: target}
+	visited := make(map[route.Vertex]struct{})
+
+	queue := &pathHeap{}
+	heap.Push(queue, target)
+
+	for queue.Len() > 0 {
+		node := heap.Pop(queue).(*pathNode)
+		if node.vertex == req.Source {
+			return g.buildRoute(req, nodes)
+		}
+		visited[node.vertex] = struct{}{}
+
+		if node.hops == maxHops {
+			continue
+		}
+
+		for chanID := range g.nodeChans[node.vertex] {
+			if _, ok := req.IgnoredChannels[chanID]; ok {
+				continue
+			}
+
+			edge := g.edges[chanID]
+			from, policy := edge.Node1, edge.Node1Policy
+			if from == node.vertex {
+				from, policy = edge.Node2, edge.Node2Policy
+			}
+
+			if _, ok := visited[from]; ok {
+				continue
+			}
+			if _, ok := req.IgnoredNodes[from]; ok {
+				continue
+			}
+
+			// The source doesn't pay fees to itself, but the
+			// channel still needs to be able to carry the amount.
+			capacity := lnwire.NewMSatFromSatoshis(edge.Capacity)
+			if capacity < node.amt {
+				continue
+			}
+
+			amt, weight := node.amt, node.weight
+			if from != req.Source {
+				if !canForward(policy, node.amt) {
+					continue
+				}
+
+				fee := forwardingFee(policy, node.amt)
+				amt += fee
+				weight += float64(fee) + float64(amt)*
+					float64(policy.TimeLockDelta)*riskFactor
+			}
+
+			prev, ok := nodes[from]
+			if ok && prev.weight <= weight {
+				continue
+			}
+
+			candidate := &pathNode{
+				vertex: from,
+				amt:    amt,
+				weight: weight,
+				hops:   node.hops + 1,
+				chanID: chanID,
+				next:   node.vertex,
+			}
+			nodes[from] = candidate
+
+			if ok && prev.index >= 0 {
+				heap.Remove(queue, prev.index)
+			}
+			heap.Push(queue, candidate)
+		}
+	}
+
+	return nil, ErrNoPathFound
+}
+
+// buildRoute assembles the route from the source to the target from the
+// search state, computing the amounts and expiries of all hops.
+func (g *GraphCache) buildRoute(req PathRequest,
+	nodes map[route.Vertex]*pathNode) (*Route, error) {
+
+	var hops []Hop
+	for node := nodes[req.Source]; node.vertex != req.Target; {
+		next := nodes[node.next]
+		hops = append(hops, Hop{
+			ChannelID:  node.chanID,
+			PubKey:     next.vertex,
+			TLVPayload: g.supportsTLV(next.vertex),
+		})
+		node = next
+	}
+
+	// Walk the route backwards to compute the amounts and expiries. The
+	// amount and expiry of a hop are those of the htlc it forwards, so they
+	// don't include the hop's own fee and time lock delta.
+	amt := req.Amount
+	cltv := req.CurrentHeight + req.FinalCltvDelta
+	for i := len(hops) - 1; i >= 0; i-- {
+		hops[i].AmtToForwardMsat = amt
+		hops[i].Expiry = cltv
+
+		// The final hop doesn't forward and doesn't charge a fee.
+		if i == len(hops)-1 {
+			continue
+		}
+
+		policy := g.policy(hops[i+1].ChannelID, hops[i].PubKey)
+		if policy == nil {
+			return nil, ErrNoPathFound
+		}
+
+		fee := forwardingFee(policy, amt)
+		hops[i].FeeMsat = fee
+		amt += fee
+		cltv += policy.TimeLockDelta
+	}
+
+	return &Route{
+		TotalTimeLock: cltv,
+		TotalFeesMsat: amt - req.Amount,
+		TotalAmtMsat:  amt,
+		Hops:          hops,
+	}, nil
+}
+
+// supportsTLV returns true if the node advertises support for the tlv onion
+// payload. The caller must hold the read lock.
+func (g *GraphCache) supportsTLV(vertex route.Vertex) bool {
+	node, ok := g.nodes[vertex]
+	if !ok {
+		return false
+	}
+
+	for _, feature := range node.Features {
+		switch feature {
+		case lnwire.TLVOnionPayloadOptional,
+			lnwire.TLVOnionPayloadRequired:
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// canForward returns true if the policy allows forwarding the amount.
+func canForward(policy *RoutingPolicy, amt lnwire.MilliSatoshi) bool {
+	switch {
+	case policy == nil, policy.Disabled:
+		return false
+
+	case amt < policy.MinHtlcMsat:
+		return false
+
+	case policy.MaxHtlcMsat != 0 && amt > policy.MaxHtlcMsat:
+		return false
+
+	default:
+		return true
+	}
+}
+
+// forwardingFee returns the fee the policy charges for forwarding the amount.
+func forwardingFee(policy *RoutingPolicy,
+	amt lnwire.MilliSatoshi) lnwire.MilliSatoshi {
+
+	return policy.FeeBaseMsat + amt*lnwire.MilliSatoshi(
+		policy.FeeRateMilliMsat,
+	)/1000000
+}
@@ -0,0 +1,93 @@
+package lndclient
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// TestFindRoute tests that the cheapest route is found, that its amounts and
+// expiries are computed correctly and that ignored nodes are avoided.
+func TestFindRoute(t *testing.T) {
+	source, cheap, expensive, target := route.Vertex{1}, route.Vertex{2},
+		route.Vertex{3}, route.Vertex{4}
+
+	cache := NewGraphCache(nil, 0)
+
+	// addChannel adds a channel with the same policy in both directions.
+	addChannel := func(chanID uint64, node1, node2 route.Vertex,
+		baseFee lnwire.MilliSatoshi, delta uint32) {
+
+		for _, nodes := range [][2]route.Vertex{
+			{node1, node2}, {node2, node1},
+		} {
+			cache.applyUpdate(&GraphTopologyUpdate{
+				ChannelEdgeUpdates: []ChannelEdgeUpdate{{
+					ChannelID:       chanID,
+					Capacity:        btcutil.Amount(100000),
+					AdvertisingNode: nodes[0],
+					ConnectingNode:  nodes[1],
+					RoutingPolicy: &RoutingPolicy{
+						FeeBaseMsat:   baseFee,
+						TimeLockDelta: delta,
+					},
+				}},
+			})
+		}
+	}
+
+	addChannel(1, source, cheap, 1000, 40)
+	addChannel(2, cheap, target, 1000, 40)
+	addChannel(3, source, expensive, 5000, 40)
+	addChannel(4, expensive, target, 5000, 40)
+
+	req := PathRequest{
+		Source:         source,
+		Target:         target,
+		Amount:         10000,
+		FinalCltvDelta: 18,
+		CurrentHeight:  100,
+	}
+
+	rt, err := cache.FindRoute(req)
+	if err != nil {
+		t.Fatalf("unable to find route: %v", err)
+	}
+
+	if len(rt.Hops) != 2 || rt.Hops[0].ChannelID != 1 ||
+		rt.Hops[1].ChannelID != 2 {
+
+		t.Fatalf("expected route over cheap node, got %+v", rt.Hops)
+	}
+
+	// Only the intermediate node charges a fee.
+	if rt.TotalFeesMsat != 1000 || rt.TotalAmtMsat != 11000 {
+		t.Fatalf("unexpected fees: %v, amount: %v", rt.TotalFeesMsat,
+			rt.TotalAmtMsat)
+	}
+	if rt.Hops[0].AmtToForwardMsat != 10000 || rt.Hops[0].FeeMsat != 1000 {
+		t.Fatalf("unexpected first hop: %+v", rt.Hops[0])
+	}
+	if rt.Hops[0].Expiry != 118 || rt.TotalTimeLock != 158 {
+		t.Fatalf("unexpected expiries: %v, %v", rt.Hops[0].Expiry,
+			rt.TotalTimeLock)
+	}
+
+	// With the cheap node ignored, the expensive route is used.
+	req.IgnoredNodes = map[route.Vertex]struct{}{cheap: {}}
+	rt, err = cache.FindRoute(req)
+	if err != nil {
+		t.Fatalf("unable to find route: %v", err)
+	}
+	if rt.Hops[0].PubKey != expensive {
+		t.Fatalf("expected route over expensive node")
+	}
+
+	// Without any usable node, no route is found.
+	req.IgnoredNodes[expensive] = struct{}{}
+	if _, err := cache.FindRoute(req); err != ErrNoPathFound {
+		t.Fatalf("expected no path, got %v", err)
+	}
+}
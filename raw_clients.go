@@ -0,0 +1,76 @@
+package lndclient
+
+import (
+	"context"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/verrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	"google.golang.org/grpc"
+)
+
+// ClientConn returns the underlying gRPC connection to lnd. This allows
+// applications to call lnd RPCs that are not wrapped by lndclient yet without
+// opening a second connection. The connection is closed by Close and Stop and
+// must not be closed by the caller.
+func (s *GrpcLndServices) ClientConn() *grpc.ClientConn {
+	return s.conn
+}
+
+// RawLightning returns the generated gRPC client of lnd's main
+// lightning service. Calls need to be authenticated with WithMacaroonAuth.
+func (s *GrpcLndServices) RawLightning() lnrpc.LightningClient {
+	return lnrpc.NewLightningClient(s.conn)
+}
+
+// RawRouter returns the generated gRPC client of the router sub server.
+// Calls need to be authenticated with WithMacaroonAuth.
+func (s *GrpcLndServices) RawRouter() routerrpc.RouterClient {
+	return routerrpc.NewRouterClient(s.conn)
+}
+
+// RawWalletKit returns the generated gRPC client of the wallet kit sub
+// server. Calls need to be authenticated with WithMacaroonAuth.
+func (s *GrpcLndServices) RawWalletKit() walletrpc.WalletKitClient {
+	return walletrpc.NewWalletKitClient(s.conn)
+}
+
+// RawSigner returns the generated gRPC client of the signer sub server.
+// Calls need to be authenticated with WithMacaroonAuth.
+func (s *GrpcLndServices) RawSigner() signrpc.SignerClient {
+	return signrpc.NewSignerClient(s.conn)
+}
+
+// RawChainNotifier returns the generated gRPC client of the chain
+// notifier sub server. Calls need to be authenticated with WithMacaroonAuth.
+func (s *GrpcLndServices) RawChainNotifier() chainrpc.ChainNotifierClient {
+	return chainrpc.NewChainNotifierClient(s.conn)
+}
+
+// RawInvoices returns the generated gRPC client of the invoices sub
+// server. Calls need to be authenticated with WithMacaroonAuth.
+func (s *GrpcLndServices) RawInvoices() invoicesrpc.InvoicesClient {
+	return invoicesrpc.NewInvoicesClient(s.conn)
+}
+
+// RawVersioner returns the generated gRPC client of the versioner sub
+// server. Calls need to be authenticated with WithMacaroonAuth.
+func (s *GrpcLndServices) RawVersioner() verrpc.VersionerClient {
+	return verrpc.NewVersionerClient(s.conn)
+}
+
+// WithMacaroonAuth returns a context that authenticates calls with the admin
+// macaroon, or the single custom macaroon if one was configured. It is meant
+// for calls through the raw clients. Per-call constraints that were added to
+// the context with WithMacaroonConstraints are applied.
+func (s *LndServices) WithMacaroonAuth(ctx context.Context) context.Context {
+	if s.macaroons == nil {
+		return ctx
+	}
+
+	return s.macaroons.adminMac.WithMacaroonAuth(ctx)
+}
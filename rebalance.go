@@ -0,0 +1,290 @@
+package lndclient
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+const (
+	// defaultRebalanceAttempts is the number of routes that are tried if
+	// the rebalance request doesn't specify a maximum.
+	defaultRebalanceAttempts = 3
+
+	// rebalanceCltvDelta is the final cltv delta of the invoice that is
+	// paid to ourselves.
+	rebalanceCltvDelta = 40
+
+	// rebalanceInvoiceExpiry is the expiry in seconds of the invoice that
+	// is paid to ourselves.
+	rebalanceInvoiceExpiry = 3600
+)
+
+// ErrNoRebalanceRoute is returned if no circular route was found that
+// satisfies the fee budget of a rebalance.
+var ErrNoRebalanceRoute = errors.New("no rebalance route found")
+
+// RebalanceRequest describes a circular payment that moves local balance from
+// one of our channels to another one.
+type RebalanceRequest struct {
+	// OutgoingChannel is the channel the payment leaves through. Its local
+	// balance decreases by the amount.
+	OutgoingChannel uint64
+
+	// IncomingPeer is the peer that forwards the payment back to us as the
+	// last hop. The local balance of our channel with that peer increases
+	// by the amount.
+	IncomingPeer route.Vertex
+
+	// Amount is the amount to move.
+	Amount btcutil.Amount
+
+	// MaxFee is the maximum total fee that is paid for the rebalance.
+	MaxFee btcutil.Amount
+
+	// MaxAttempts is the maximum number of routes that are tried. If zero,
+	// a default of 3 is used.
+	MaxAttempts int
+}
+
+// RebalanceResult is the outcome of a successful rebalance.
+type RebalanceResult struct {
+	// Route is the circular route the payment succeeded over.
+	Route *Route
+
+	// Preimage is the preimage of the invoice that was paid.
+	Preimage lntypes.Preimage
+
+	// PaidFee is the total fee that was paid.
+	PaidFee lnwire.MilliSatoshi
+
+	// Attempts is the number of routes that were tried.
+	Attempts int
+}
+
+// Rebalance moves local balance from one of our channels to another one by
+// paying a hold invoice to ourselves over a circular route. Routes are found
+// with QueryRoutes and tried one after the other with SendToRoute. Every
+// failed attempt is reported to mission control by lnd, so later attempts
+// avoid the failed channels. The invoice is settled as soon as the htlc of an
+// attempt is accepted. If no attempt succeeds, the invoice is canceled.
+//
+// Routes aren't built with BuildRoute, because it can't take the fee budget
+// and the failed pairs into account. Callers that want to rebalance over a
+// fixed path can use BuildRoute with SendToRoute directly.
+func Rebalance(ctx context.Context, lnd *LndServices,
+	req RebalanceRequest) (*RebalanceResult, error) {
+
+	if req.Amount <= 0 {
+		return nil, errors.New("rebalance amount must be positive")
+	}
+
+	maxAttempts := req.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = defaultRebalanceAttempts
+	}
+
+	var preimage lntypes.Preimage
+	if _, err := rand.Read(preimage[:]); err != nil {
+		return nil, err
+	}
+	hash := preimage.Hash()
+
+	_, err := lnd.Invoices.AddHoldInvoice(ctx, &invoicesrpc.AddInvoiceData{
+		Memo:       "rebalance",
+		Hash:       &hash,
+		Value:      lnwire.NewMSatFromSatoshis(req.Amount),
+		Expiry:     rebalanceInvoiceExpiry,
+		CltvExpiry: rebalanceCltvDelta,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to add invoice: %v", err)
+	}
+
+	// SendToRoute blocks while the htlc is held, so we settle the invoice
+	// from a separate goroutine. If settling fails, the attempts are
+	// canceled.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	updates, updateErrs, err := lnd.Invoices.SubscribeSingleInvoice(
+		ctx, hash,
+	)
+	if err != nil {
+		cancelRebalanceInvoice(lnd, hash)
+		return nil, fmt.Errorf("unable to subscribe to invoice: %v",
+			err)
+	}
+
+	var wg sync.WaitGroup
+	settleErr := make(chan error, 1)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		err := settleOnAccept(
+			ctx, lnd.Invoices, preimage, updates, updateErrs,
+		)
+
+		// Errors caused by canceling the context once the attempts
+		// are done are expected.
+		if err != nil && ctx.Err() == nil {
+			settleErr <- err
+			cancel()
+		}
+	}()
+
+	result, err := rebalance(ctx, lnd, req, hash, maxAttempts)
+	cancel()
+	wg.Wait()
+
+	select {
+	case err = <-settleErr:
+		err = fmt.Errorf("unable to settle invoice: %v", err)
+
+	default:
+	}
+
+	if err != nil {
+		cancelRebalanceInvoice(lnd, hash)
+		return nil, err
+	}
+
+	result.Preimage = preimage
+	return result, nil
+}
+
+// settleOnAccept settles the hold invoice of a rebalance once it is accepted.
+// It returns when the invoice is settled, the update stream fails or the
+// context is canceled.
+func settleOnAccept(ctx context.Context, invoices InvoicesClient,
+	preimage lntypes.Preimage, updates <-chan InvoiceUpdate,
+	errChan <-chan error) error {
+
+	for {
+		select {
+		case update := <-updates:
+			if update.State != channeldb.ContractAccepted {
+				continue
+			}
+
+			return invoices.SettleInvoice(ctx, preimage)
+
+		case err := <-errChan:
+			return err
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// cancelRebalanceInvoice cancels the invoice of a failed rebalance so that it
+// can't be paid anymore. We use a fresh context, the rebalance could have
+// failed because the caller's context was canceled.
+func cancelRebalanceInvoice(lnd *LndServices, hash lntypes.Hash) {
+	err := lnd.Invoices.CancelInvoice(context.Background(), hash)
+	if err != nil {
+		log.Errorf("Unable to cancel rebalance invoice %v: %v", hash,
+			err)
+	}
+}
+
+// rebalance tries circular routes until a payment to the invoice with the
+// given hash succeeds or the maximum number of attempts is reached.
+func rebalance(ctx context.Context, lnd *LndServices, req RebalanceRequest,
+	hash lntypes.Hash, maxAttempts int) (*RebalanceResult, error) {
+
+	self := route.Vertex(lnd.NodePubkey)
+	outgoingChannel := req.OutgoingChannel
+	incomingPeer := req.IncomingPeer
+	amtMsat := lnwire.NewMSatFromSatoshis(req.Amount)
+
+	var ignoredPairs []NodePair
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		routes, err := lnd.Client.QueryRoutes(ctx, QueryRoutesRequest{
+			PubKey:            self,
+			AmtMsat:           amtMsat,
+			FinalCLTVDelta:    rebalanceCltvDelta,
+			MaxFee:            req.MaxFee,
+			IgnoredPairs:      ignoredPairs,
+			UseMissionControl: true,
+			OutgoingChannel:   &outgoingChannel,
+			LastHop:           &incomingPeer,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoRebalanceRoute,
+				err)
+		}
+		if len(routes.Routes) == 0 {
+			return nil, ErrNoRebalanceRoute
+		}
+
+		rt := &routes.Routes[0]
+		htlc, err := lnd.Router.SendToRoute(ctx, hash, rt)
+		if err != nil {
+			return nil, err
+		}
+
+		if htlc.Status == lnrpc.HTLCAttempt_SUCCEEDED {
+			return &RebalanceResult{
+				Route:    rt,
+				PaidFee:  rt.TotalFeesMsat,
+				Attempts: attempt,
+			}, nil
+		}
+
+		if htlc.Failure == nil {
+			return nil, fmt.Errorf("rebalance htlc %v without "+
+				"failure", htlc.Status)
+		}
+
+		// If the failure comes from ourselves as the final node,
+		// trying other routes won't help.
+		failureIndex := int(htlc.Failure.FailureSourceIndex)
+		if failureIndex >= len(rt.Hops) {
+			return nil, fmt.Errorf("rebalance failed at final "+
+				"hop: %v", htlc.Failure.Code)
+		}
+
+		log.Debugf("Rebalance attempt %v failed at hop %v: %v",
+			attempt, failureIndex, htlc.Failure.Code)
+
+		// Ignore the channel that the failing node couldn't forward
+		// over in the next attempts. Index zero is our own node.
+		from := self
+		if failureIndex > 0 {
+			from = rt.Hops[failureIndex-1].PubKey
+		}
+		ignoredPairs = append(ignoredPairs, NodePair{
+			From: from,
+			To:   rt.Hops[failureIndex].PubKey,
+		})
+	}
+
+	return nil, fmt.Errorf("%w after %v attempts", ErrNoRebalanceRoute,
+		maxAttempts)
+}
+
+// RebalanceAmount returns the amount that needs to be moved out of a channel
+// for its local balance to reach the given target ratio of its capacity. If
+// the local balance is already at or below the target, zero is returned.
+func RebalanceAmount(channel ChannelInfo, targetRatio float64) btcutil.Amount {
+	target := btcutil.Amount(float64(channel.Capacity) * targetRatio)
+	if channel.LocalBalance <= target {
+		return 0
+	}
+
+	return channel.LocalBalance - target
+}
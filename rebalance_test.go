@@ -0,0 +1,180 @@
+package lndclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// TestRebalanceAmount tests the amount that needs to be moved out of a
+// channel to reach a target local balance ratio.
+func TestRebalanceAmount(t *testing.T) {
+	channel := ChannelInfo{
+		Capacity:     1000000,
+		LocalBalance: 800000,
+	}
+
+	testCases := []struct {
+		name     string
+		target   float64
+		expected btcutil.Amount
+	}{{
+		name:     "above target",
+		target:   0.5,
+		expected: 300000,
+	}, {
+		name:     "at target",
+		target:   0.8,
+		expected: 0,
+	}, {
+		name:     "below target",
+		target:   0.9,
+		expected: 0,
+	}}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			amt := RebalanceAmount(channel, testCase.target)
+			if amt != testCase.expected {
+				t.Fatalf("expected %v, got %v",
+					testCase.expected, amt)
+			}
+		})
+	}
+}
+
+// rebalanceClient is a lightning client that returns the same route for every
+// query and records the requests.
+type rebalanceClient struct {
+	LightningClient
+
+	route    Route
+	requests []QueryRoutesRequest
+}
+
+func (c *rebalanceClient) QueryRoutes(_ context.Context,
+	req QueryRoutesRequest) (*QueryRoutesResponse, error) {
+
+	c.requests = append(c.requests, req)
+
+	return &QueryRoutesResponse{
+		Routes: []Route{c.route},
+	}, nil
+}
+
+// rebalanceRouter is a router client that resolves the htlcs sent to a route
+// with a fixed list of attempts.
+type rebalanceRouter struct {
+	RouterClient
+
+	attempts []*HtlcAttempt
+}
+
+func (r *rebalanceRouter) SendToRoute(_ context.Context, _ lntypes.Hash,
+	_ *Route) (*HtlcAttempt, error) {
+
+	attempt := r.attempts[0]
+	r.attempts = r.attempts[1:]
+
+	return attempt, nil
+}
+
+// TestRebalanceAttempts tests that failed rebalance attempts are retried
+// without the pair that failed to forward.
+func TestRebalanceAttempts(t *testing.T) {
+	self := route.Vertex{1}
+	nodeA := route.Vertex{2}
+	nodeB := route.Vertex{3}
+
+	rt := Route{
+		Hops: []Hop{
+			{ChannelID: 1, PubKey: nodeA},
+			{ChannelID: 2, PubKey: nodeB},
+			{ChannelID: 3, PubKey: self},
+		},
+	}
+
+	failed := func(index uint32) *HtlcAttempt {
+		return &HtlcAttempt{
+			Status: lnrpc.HTLCAttempt_FAILED,
+			Failure: &HtlcFailure{
+				FailureSourceIndex: index,
+			},
+		}
+	}
+	succeeded := &HtlcAttempt{
+		Status: lnrpc.HTLCAttempt_SUCCEEDED,
+	}
+
+	testCases := []struct {
+		name     string
+		attempts []*HtlcAttempt
+		ignored  []NodePair
+		err      error
+	}{{
+		name:     "first hop failed",
+		attempts: []*HtlcAttempt{failed(0), succeeded},
+		ignored:  []NodePair{{From: self, To: nodeA}},
+	}, {
+		name:     "intermediate hop failed",
+		attempts: []*HtlcAttempt{failed(1), succeeded},
+		ignored:  []NodePair{{From: nodeA, To: nodeB}},
+	}, {
+		name:     "all attempts failed",
+		attempts: []*HtlcAttempt{failed(1), failed(2)},
+		ignored:  []NodePair{{From: nodeA, To: nodeB}},
+		err:      ErrNoRebalanceRoute,
+	}}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &rebalanceClient{route: rt}
+			lnd := &LndServices{
+				Client: client,
+				Router: &rebalanceRouter{
+					attempts: testCase.attempts,
+				},
+				NodePubkey: [33]byte(self),
+			}
+
+			result, err := rebalance(
+				context.Background(), lnd, RebalanceRequest{
+					Amount: 1000,
+				}, lntypes.Hash{}, len(testCase.attempts),
+			)
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("expected error %v, got %v",
+					testCase.err, err)
+			}
+			if err == nil && result.Attempts != 2 {
+				t.Fatalf("expected 2 attempts, got %v",
+					result.Attempts)
+			}
+
+			// The pairs that failed are ignored from the next
+			// attempt on.
+			last := client.requests[len(client.requests)-1]
+			if len(last.IgnoredPairs) != len(testCase.ignored) {
+				t.Fatalf("expected %v ignored pairs, got %v",
+					len(testCase.ignored),
+					len(last.IgnoredPairs))
+			}
+			for i, pair := range last.IgnoredPairs {
+				if pair != testCase.ignored[i] {
+					t.Fatalf("expected ignored pair %v, "+
+						"got %v", testCase.ignored[i],
+						pair)
+				}
+			}
+		})
+	}
+}
@@ -3,10 +3,14 @@ package lndclient
 import (
 	"context"
 	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcutil"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -32,6 +36,258 @@ type RouterClient interface {
 	// payment update stream and an error stream.
 	TrackPayment(ctx context.Context, hash lntypes.Hash) (
 		chan PaymentStatus, chan error, error)
+
+	// BuildRoute builds a fully specified route based on a list of pubkeys.
+	// If amount is nil, the minimum routable amount is used.
+	BuildRoute(ctx context.Context, amt *lnwire.MilliSatoshi,
+		finalCltvDelta int32, outgoingChannel *uint64,
+		hops []route.Vertex) (*Route, error)
+
+	// SendToRoute attempts to make a payment via the specified route. The
+	// call blocks until the htlc attempt is resolved.
+	SendToRoute(ctx context.Context, hash lntypes.Hash, route *Route) (
+		*HtlcAttempt, error)
+
+	// ResetMissionControl clears all mission control state and starts
+	// with a clean slate.
+	ResetMissionControl(ctx context.Context) error
+
+	// QueryMissionControl returns the internal mission control state.
+	QueryMissionControl(ctx context.Context) ([]PairHistory, error)
+
+	// QueryProbability returns the probability that lnd assigns to a
+	// successful payment of the given amount over the directed node pair.
+	QueryProbability(ctx context.Context, fromNode, toNode route.Vertex,
+		amt lnwire.MilliSatoshi) (float64, *PairData, error)
+
+	// InterceptHtlcs intercepts htlcs that are forwarded by lnd and passes
+	// them to the handler to decide on their resolution. The call blocks
+	// until the context is canceled or the interceptor stream fails.
+	InterceptHtlcs(ctx context.Context,
+		handler HtlcInterceptHandler) error
+
+	// SubscribeHtlcEvents subscribes to the events of htlcs that are sent,
+	// received or forwarded by lnd.
+	SubscribeHtlcEvents(ctx context.Context) (<-chan *HtlcEvent,
+		<-chan error, error)
+}
+
+// InterceptorAction represents the different actions that can be taken for
+// an intercepted htlc.
+type InterceptorAction uint8
+
+const (
+	// InterceptorActionSettle settles the htlc with the preimage provided.
+	InterceptorActionSettle InterceptorAction = iota
+
+	// InterceptorActionFail fails the htlc back to the sender.
+	InterceptorActionFail
+
+	// InterceptorActionResume resumes the forward of the htlc.
+	InterceptorActionResume
+)
+
+// String returns a string representation of the interceptor action.
+func (a InterceptorAction) String() string {
+	switch a {
+	case InterceptorActionSettle:
+		return "Settle"
+
+	case InterceptorActionFail:
+		return "Fail"
+
+	case InterceptorActionResume:
+		return "Resume"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// InterceptedHtlc contains information about a htlc that was intercepted in
+// lnd's switch.
+type InterceptedHtlc struct {
+	// IncomingCircuitKey is lnd's unique identifier for the incoming htlc.
+	IncomingCircuitKey channeldb.CircuitKey
+
+	// Hash is the payment hash for the htlc.
+	Hash lntypes.Hash
+
+	// AmountInMsat is the incoming htlc amount.
+	AmountInMsat lnwire.MilliSatoshi
+
+	// AmountOutMsat is the outgoing htlc amount.
+	AmountOutMsat lnwire.MilliSatoshi
+
+	// IncomingExpiryHeight is the expiry height of the incoming htlc.
+	IncomingExpiryHeight uint32
+
+	// OutgoingExpiryHeight is the expiry height of the outgoing htlc.
+	OutgoingExpiryHeight uint32
+
+	// OutgoingChannelID is the outgoing channel id proposed by the sender.
+	// Since lnd has non-strict forwarding, this may not be the channel that
+	// the htlc ends up being forwarded on.
+	OutgoingChannelID lnwire.ShortChannelID
+
+	// CustomRecords holds the custom TLV records that were added to the
+	// payment.
+	CustomRecords map[uint64][]byte
+}
+
+// InterceptedHtlcResponse contains the actions that must be taken for an
+// intercepted htlc.
+type InterceptedHtlcResponse struct {
+	// Action is the action that should be taken for the htlc.
+	Action InterceptorAction
+
+	// Preimage is the preimage to settle the htlc with. It must be set if
+	// the action is InterceptorActionSettle.
+	Preimage *lntypes.Preimage
+}
+
+// HtlcInterceptHandler is a function type that is used to process htlcs
+// intercepted by lnd. It is called for every intercepted htlc and its
+// response determines how the htlc is resolved.
+type HtlcInterceptHandler func(context.Context,
+	InterceptedHtlc) (*InterceptedHtlcResponse, error)
+
+// HtlcEventOutcome describes what happened to an htlc in an htlc event.
+type HtlcEventOutcome uint8
+
+const (
+	// HtlcEventOutcomeUnknown is set for events that lnd reported with an
+	// unknown outcome.
+	HtlcEventOutcomeUnknown HtlcEventOutcome = iota
+
+	// HtlcEventOutcomeForward indicates that the htlc was forwarded to
+	// the next hop.
+	HtlcEventOutcomeForward
+
+	// HtlcEventOutcomeForwardFail indicates that the htlc failed
+	// downstream of our node.
+	HtlcEventOutcomeForwardFail
+
+	// HtlcEventOutcomeSettle indicates that the htlc was settled.
+	HtlcEventOutcomeSettle
+
+	// HtlcEventOutcomeLinkFail indicates that the htlc failed at our
+	// node.
+	HtlcEventOutcomeLinkFail
+)
+
+// String returns a string representation of the htlc event outcome.
+func (h HtlcEventOutcome) String() string {
+	switch h {
+	case HtlcEventOutcomeForward:
+		return "Forward"
+
+	case HtlcEventOutcomeForwardFail:
+		return "ForwardFail"
+
+	case HtlcEventOutcomeSettle:
+		return "Settle"
+
+	case HtlcEventOutcomeLinkFail:
+		return "LinkFail"
+
+	default:
+		return "Unknown"
+	}
+}
+
+// HtlcEvent is an event in the lifecycle of an htlc that is sent, received or
+// forwarded by lnd.
+type HtlcEvent struct {
+	// IncomingChannelID is the channel the htlc arrived on. It is zero
+	// for htlcs that we send.
+	IncomingChannelID uint64
+
+	// OutgoingChannelID is the channel the htlc left on. It is zero for
+	// htlcs that we receive.
+	OutgoingChannelID uint64
+
+	// IncomingHtlcID is the index of the htlc on the incoming channel.
+	IncomingHtlcID uint64
+
+	// OutgoingHtlcID is the index of the htlc on the outgoing channel.
+	OutgoingHtlcID uint64
+
+	// Timestamp is the time of the event.
+	Timestamp time.Time
+
+	// EventType indicates whether the htlc was sent, received or
+	// forwarded.
+	EventType routerrpc.HtlcEvent_EventType
+
+	// Outcome is what happened to the htlc.
+	Outcome HtlcEventOutcome
+}
+
+// PairData contains the mission control history of a node pair.
+type PairData struct {
+	// FailTime is the time of the last failure. It is the zero time if no
+	// failure was recorded.
+	FailTime time.Time
+
+	// FailAmt is the lowest amount that failed to be forwarded.
+	FailAmt lnwire.MilliSatoshi
+
+	// SuccessTime is the time of the last success. It is the zero time if
+	// no success was recorded.
+	SuccessTime time.Time
+
+	// SuccessAmt is the highest amount that was successfully forwarded.
+	SuccessAmt lnwire.MilliSatoshi
+}
+
+// PairHistory contains the mission control state of a directed node pair.
+type PairHistory struct {
+	// NodeFrom is the source node of the pair.
+	NodeFrom route.Vertex
+
+	// NodeTo is the destination node of the pair.
+	NodeTo route.Vertex
+
+	// PairData holds the history of the pair.
+	PairData
+}
+
+// HtlcFailure describes why an htlc attempt failed.
+type HtlcFailure struct {
+	// Code is the failure code returned by the failing node.
+	Code lnrpc.Failure_FailureCode
+
+	// FailureSourceIndex is the position in the route of the node that
+	// generated the failure. Index zero is our own node.
+	FailureSourceIndex uint32
+
+	// HtlcMsat is the htlc amount that the failing node reported, if
+	// applicable to the failure code.
+	HtlcMsat lnwire.MilliSatoshi
+
+	// Height is the block height the failing node reported, if applicable
+	// to the failure code.
+	Height uint32
+}
+
+// HtlcAttempt describes an htlc that was sent as (part of) a payment.
+type HtlcAttempt struct {
+	// Status is the state of the htlc attempt.
+	Status lnrpc.HTLCAttempt_HTLCStatus
+
+	// Route is the route the htlc was sent over.
+	Route *Route
+
+	// AttemptTime is the time at which the htlc was sent.
+	AttemptTime time.Time
+
+	// ResolveTime is the time at which the htlc was settled or failed. It
+	// is the zero time if the htlc is still in flight.
+	ResolveTime time.Time
+
+	// Failure contains the failure details if the htlc failed.
+	Failure *HtlcFailure
 }
 
 // PaymentStatus describe the state of a payment.
@@ -127,6 +383,9 @@ type SendPaymentRequest struct {
 type routerClient struct {
 	client       routerrpc.RouterClient
 	routerKitMac serializedMacaroon
+
+	wg   sync.WaitGroup
+	quit chan struct{}
 }
 
 func newRouterClient(conn *grpc.ClientConn,
@@ -135,9 +394,21 @@ func newRouterClient(conn *grpc.ClientConn,
 	return &routerClient{
 		client:       routerrpc.NewRouterClient(conn),
 		routerKitMac: routerKitMac,
+		quit:         make(chan struct{}),
 	}
 }
 
+// stop signals all goroutines of the client to exit, even if they are
+// blocked delivering updates to a caller that stopped reading.
+func (r *routerClient) stop() {
+	close(r.quit)
+}
+
+// WaitForFinished waits until all goroutines of the client have exited.
+func (r *routerClient) WaitForFinished() {
+	r.wg.Wait()
+}
+
 // SendPayment attempts to route a payment to the final destination. The call
 // returns a payment update stream and an error stream.
 func (r *routerClient) SendPayment(ctx context.Context,
@@ -223,6 +494,539 @@ func (r *routerClient) TrackPayment(ctx context.Context,
 	return r.trackPayment(ctx, stream)
 }
 
+// BuildRoute builds a fully specified route based on a list of pubkeys. The
+// hops must start with the first hop after our own node and end with the
+// destination. If amount is nil, the minimum routable amount is used. If an
+// outgoing channel is provided, the route will use that channel for the first
+// hop.
+func (r *routerClient) BuildRoute(ctx context.Context,
+	amt *lnwire.MilliSatoshi, finalCltvDelta int32, outgoingChannel *uint64,
+	hops []route.Vertex) (*Route, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcReq := &routerrpc.BuildRouteRequest{
+		FinalCltvDelta: finalCltvDelta,
+		HopPubkeys:     make([][]byte, len(hops)),
+	}
+	for i, hop := range hops {
+		hop := hop
+		rpcReq.HopPubkeys[i] = hop[:]
+	}
+
+	if amt != nil {
+		rpcReq.AmtMsat = int64(*amt)
+	}
+
+	if outgoingChannel != nil {
+		rpcReq.OutgoingChanId = *outgoingChannel
+	}
+
+	rpcCtx = r.routerKitMac.WithMacaroonAuth(rpcCtx)
+	resp, err := r.client.BuildRoute(rpcCtx, rpcReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalRoute(resp.Route)
+}
+
+// SendToRoute attempts to make a payment via the specified route. The call
+// blocks until the htlc attempt is resolved and returns the resulting attempt.
+// A failed attempt is not returned as an error, the failure details are set on
+// the attempt instead.
+func (r *routerClient) SendToRoute(ctx context.Context, hash lntypes.Hash,
+	route *Route) (*HtlcAttempt, error) {
+
+	rpcRoute, err := marshallRoute(route)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create no timeout context as this call can block for a long time.
+	rpcCtx := r.routerKitMac.WithMacaroonAuth(ctx)
+	resp, err := r.client.SendToRouteV2(
+		rpcCtx, &routerrpc.SendToRouteRequest{
+			PaymentHash: hash[:],
+			Route:       rpcRoute,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshallHtlcAttempt(resp)
+}
+
+// ResetMissionControl clears all mission control state and starts with a clean
+// slate.
+func (r *routerClient) ResetMissionControl(ctx context.Context) error {
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = r.routerKitMac.WithMacaroonAuth(rpcCtx)
+	_, err := r.client.ResetMissionControl(
+		rpcCtx, &routerrpc.ResetMissionControlRequest{},
+	)
+
+	return err
+}
+
+// QueryMissionControl returns the internal mission control state, containing
+// the payment history of all node pairs mission control has recorded.
+func (r *routerClient) QueryMissionControl(ctx context.Context) ([]PairHistory,
+	error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = r.routerKitMac.WithMacaroonAuth(rpcCtx)
+	resp, err := r.client.QueryMissionControl(
+		rpcCtx, &routerrpc.QueryMissionControlRequest{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]PairHistory, len(resp.Pairs))
+	for i, pair := range resp.Pairs {
+		from, err := route.NewVertexFromBytes(pair.NodeFrom)
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := route.NewVertexFromBytes(pair.NodeTo)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs[i] = PairHistory{
+			NodeFrom: from,
+			NodeTo:   to,
+			PairData: unmarshallPairData(pair.History),
+		}
+	}
+
+	return pairs, nil
+}
+
+// QueryProbability returns the probability that lnd assigns to a successful
+// payment of the given amount over the directed node pair, together with the
+// mission control history for the pair that the estimate is based on.
+func (r *routerClient) QueryProbability(ctx context.Context, fromNode,
+	toNode route.Vertex, amt lnwire.MilliSatoshi) (float64, *PairData,
+	error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = r.routerKitMac.WithMacaroonAuth(rpcCtx)
+	resp, err := r.client.QueryProbability(
+		rpcCtx, &routerrpc.QueryProbabilityRequest{
+			FromNode: fromNode[:],
+			ToNode:   toNode[:],
+			AmtMsat:  int64(amt),
+		},
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	history := unmarshallPairData(resp.History)
+
+	return resp.Probability, &history, nil
+}
+
+// InterceptHtlcs intercepts htlcs that are forwarded by lnd and passes them to
+// the handler to decide on their resolution. Every htlc is handled in its own
+// goroutine so that a slow handler does not hold up other forwards. If the
+// handler fails for an htlc, that htlc is failed back while other htlcs are
+// still intercepted. The call blocks until the context is canceled or the
+// interceptor stream fails, and only returns once all handlers have
+// returned. Once it returns, htlcs are no longer intercepted and lnd resumes
+// any htlcs that were not resolved yet.
+func (r *routerClient) InterceptHtlcs(ctx context.Context,
+	handler HtlcInterceptHandler) error {
+
+	// Cancel the stream when we exit so that lnd stops intercepting and
+	// any outstanding handlers are notified.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rpcCtx := r.routerKitMac.WithMacaroonAuth(ctx)
+	stream, err := r.client.HtlcInterceptor(rpcCtx)
+	if err != nil {
+		return err
+	}
+
+	// Buffer the error channel so that the first goroutine to fail does
+	// not block. Subsequent errors are dropped because we only report the
+	// first failure.
+	errChan := make(chan error, 1)
+	sendErr := func(err error) {
+		select {
+		case errChan <- err:
+		default:
+		}
+	}
+
+	// The grpc stream does not allow concurrent sends, so we serialize
+	// the responses of the handler goroutines.
+	var sendMtx sync.Mutex
+	send := func(resp *routerrpc.ForwardHtlcInterceptResponse) error {
+		sendMtx.Lock()
+		defer sendMtx.Unlock()
+
+		return stream.Send(resp)
+	}
+
+	// Wait for the receive loop and all handlers to exit before we
+	// return. Canceling the context unblocks them. The goroutines are
+	// tracked by the client too, so that stopping the client waits for
+	// them.
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	wg.Add(1)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer wg.Done()
+
+		for {
+			request, err := stream.Recv()
+			if err != nil {
+				sendErr(err)
+				return
+			}
+
+			wg.Add(1)
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				defer wg.Done()
+
+				err := handleInterceptedHtlc(
+					ctx, request, handler, send,
+				)
+				if err != nil {
+					sendErr(err)
+				}
+			}()
+		}
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-r.quit:
+		return ErrClientStopped
+	}
+}
+
+// SubscribeHtlcEvents subscribes to the events of htlcs that are sent,
+// received or forwarded by lnd.
+func (r *routerClient) SubscribeHtlcEvents(ctx context.Context) (
+	<-chan *HtlcEvent, <-chan error, error) {
+
+	stream, err := r.client.SubscribeHtlcEvents(
+		r.routerKitMac.WithMacaroonAuth(ctx),
+		&routerrpc.SubscribeHtlcEventsRequest{},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eventChan := make(chan *HtlcEvent)
+	errChan := make(chan error, 1)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			rpcEvent, err := stream.Recv()
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			select {
+			case eventChan <- unmarshallHtlcEvent(rpcEvent):
+			case <-ctx.Done():
+				return
+			case <-r.quit:
+				return
+			}
+		}
+	}()
+
+	return eventChan, errChan, nil
+}
+
+// unmarshallHtlcEvent converts an rpc htlc event to an HtlcEvent.
+func unmarshallHtlcEvent(rpcEvent *routerrpc.HtlcEvent) *HtlcEvent {
+	event := &HtlcEvent{
+		IncomingChannelID: rpcEvent.IncomingChannelId,
+		OutgoingChannelID: rpcEvent.OutgoingChannelId,
+		IncomingHtlcID:    rpcEvent.IncomingHtlcId,
+		OutgoingHtlcID:    rpcEvent.OutgoingHtlcId,
+		Timestamp:         time.Unix(0, int64(rpcEvent.TimestampNs)),
+		EventType:         rpcEvent.EventType,
+	}
+
+	switch rpcEvent.Event.(type) {
+	case *routerrpc.HtlcEvent_ForwardEvent:
+		event.Outcome = HtlcEventOutcomeForward
+
+	case *routerrpc.HtlcEvent_ForwardFailEvent:
+		event.Outcome = HtlcEventOutcomeForwardFail
+
+	case *routerrpc.HtlcEvent_SettleEvent:
+		event.Outcome = HtlcEventOutcomeSettle
+
+	case *routerrpc.HtlcEvent_LinkFailEvent:
+		event.Outcome = HtlcEventOutcomeLinkFail
+
+	default:
+		event.Outcome = HtlcEventOutcomeUnknown
+	}
+
+	return event
+}
+
+// handleInterceptedHtlc passes a single intercepted htlc to the handler and
+// sends the resolution back to lnd. If the htlc can't be handled, it is
+// failed back. Only errors of the stream are returned, as they affect all
+// htlcs.
+func handleInterceptedHtlc(ctx context.Context,
+	request *routerrpc.ForwardHtlcInterceptRequest,
+	handler HtlcInterceptHandler,
+	send func(*routerrpc.ForwardHtlcInterceptResponse) error) error {
+
+	// Without a circuit key we can't refer to the htlc in our response.
+	// lnd resumes it once the interceptor exits.
+	if request.IncomingCircuitKey == nil {
+		log.Errorf("Intercepted htlc without circuit key")
+		return nil
+	}
+
+	rpcResp, err := resolveInterceptedHtlc(ctx, request, handler)
+	if err != nil {
+		log.Errorf("Failing intercepted htlc %v: %v",
+			request.IncomingCircuitKey, err)
+
+		rpcResp = &routerrpc.ForwardHtlcInterceptResponse{
+			IncomingCircuitKey: request.IncomingCircuitKey,
+		}
+		rpcResp.Action = routerrpc.ResolveHoldForwardAction_FAIL
+	}
+
+	return send(rpcResp)
+}
+
+// resolveInterceptedHtlc passes a single intercepted htlc to the handler and
+// returns its resolution.
+func resolveInterceptedHtlc(ctx context.Context,
+	request *routerrpc.ForwardHtlcInterceptRequest,
+	handler HtlcInterceptHandler) (*routerrpc.ForwardHtlcInterceptResponse,
+	error) {
+
+	htlc, err := unmarshallInterceptedHtlc(request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := handler(ctx, *htlc)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshallInterceptedHtlcResponse(
+		request.IncomingCircuitKey, resp,
+	)
+}
+
+// unmarshallInterceptedHtlc unmarshalls an rpc intercept request.
+func unmarshallInterceptedHtlc(
+	request *routerrpc.ForwardHtlcInterceptRequest) (*InterceptedHtlc,
+	error) {
+
+	if request.IncomingCircuitKey == nil {
+		return nil, errors.New("intercepted htlc without circuit key")
+	}
+
+	hash, err := lntypes.MakeHash(request.PaymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	chanID := lnwire.NewShortChanIDFromInt(
+		request.IncomingCircuitKey.ChanId,
+	)
+
+	return &InterceptedHtlc{
+		IncomingCircuitKey: channeldb.CircuitKey{
+			ChanID: chanID,
+			HtlcID: request.IncomingCircuitKey.HtlcId,
+		},
+		Hash: hash,
+		AmountInMsat: lnwire.MilliSatoshi(
+			request.IncomingAmountMsat,
+		),
+		AmountOutMsat: lnwire.MilliSatoshi(
+			request.OutgoingAmountMsat,
+		),
+		IncomingExpiryHeight: request.IncomingExpiry,
+		OutgoingExpiryHeight: request.OutgoingExpiry,
+		OutgoingChannelID: lnwire.NewShortChanIDFromInt(
+			request.OutgoingRequestedChanId,
+		),
+		CustomRecords: request.CustomRecords,
+	}, nil
+}
+
+// marshallInterceptedHtlcResponse marshalls the handler response for an
+// intercepted htlc.
+func marshallInterceptedHtlcResponse(key *routerrpc.CircuitKey,
+	resp *InterceptedHtlcResponse) (*routerrpc.ForwardHtlcInterceptResponse,
+	error) {
+
+	if resp == nil {
+		return nil, errors.New("no intercept response provided")
+	}
+
+	rpcResp := &routerrpc.ForwardHtlcInterceptResponse{
+		IncomingCircuitKey: key,
+	}
+
+	switch resp.Action {
+	case InterceptorActionSettle:
+		if resp.Preimage == nil {
+			return nil, errors.New("preimage required for settle")
+		}
+
+		rpcResp.Action = routerrpc.ResolveHoldForwardAction_SETTLE
+		rpcResp.Preimage = resp.Preimage[:]
+
+	case InterceptorActionFail:
+		rpcResp.Action = routerrpc.ResolveHoldForwardAction_FAIL
+
+	case InterceptorActionResume:
+		rpcResp.Action = routerrpc.ResolveHoldForwardAction_RESUME
+
+	default:
+		return nil, fmt.Errorf("unknown interceptor action: %v",
+			resp.Action)
+	}
+
+	return rpcResp, nil
+}
+
+// unmarshallPairData unmarshalls rpc mission control pair data.
+func unmarshallPairData(history *routerrpc.PairData) PairData {
+	var data PairData
+	if history == nil {
+		return data
+	}
+
+	data.FailAmt = lnwire.MilliSatoshi(history.FailAmtMsat)
+	data.SuccessAmt = lnwire.MilliSatoshi(history.SuccessAmtMsat)
+
+	// Only set times if they are non-zero, because 0 unix time is not the
+	// same as a zero time struct.
+	if history.FailTime != 0 {
+		data.FailTime = time.Unix(history.FailTime, 0)
+	}
+
+	if history.SuccessTime != 0 {
+		data.SuccessTime = time.Unix(history.SuccessTime, 0)
+	}
+
+	return data
+}
+
+// marshallRoute marshalls a route.
+func marshallRoute(route *Route) (*lnrpc.Route, error) {
+	if route == nil {
+		return nil, errors.New("no route provided")
+	}
+
+	rpcHops := make([]*lnrpc.Hop, len(route.Hops))
+	for i, hop := range route.Hops {
+		rpcHop := &lnrpc.Hop{
+			ChanId:           hop.ChannelID,
+			Expiry:           hop.Expiry,
+			AmtToForwardMsat: int64(hop.AmtToForwardMsat),
+			FeeMsat:          int64(hop.FeeMsat),
+			PubKey:           hop.PubKey.String(),
+			TlvPayload:       hop.TLVPayload,
+			CustomRecords:    hop.CustomRecords,
+		}
+
+		if hop.MPP != nil {
+			addr := hop.MPP.PaymentAddr()
+			rpcHop.MppRecord = &lnrpc.MPPRecord{
+				PaymentAddr:  addr[:],
+				TotalAmtMsat: int64(hop.MPP.TotalMsat()),
+			}
+		}
+
+		rpcHops[i] = rpcHop
+	}
+
+	return &lnrpc.Route{
+		TotalTimeLock: route.TotalTimeLock,
+		TotalFeesMsat: int64(route.TotalFeesMsat),
+		TotalAmtMsat:  int64(route.TotalAmtMsat),
+		Hops:          rpcHops,
+	}, nil
+}
+
+// unmarshallHtlcAttempt unmarshalls an rpc htlc attempt.
+func unmarshallHtlcAttempt(rpcAttempt *lnrpc.HTLCAttempt) (*HtlcAttempt,
+	error) {
+
+	attempt := &HtlcAttempt{
+		Status:      rpcAttempt.Status,
+		AttemptTime: time.Unix(0, rpcAttempt.AttemptTimeNs),
+	}
+
+	if rpcAttempt.Route != nil {
+		route, err := unmarshalRoute(rpcAttempt.Route)
+		if err != nil {
+			return nil, err
+		}
+		attempt.Route = route
+	}
+
+	// Only set resolve time if it is non-zero, because 0 unix time is not
+	// the same as a zero time struct.
+	if rpcAttempt.ResolveTimeNs != 0 {
+		attempt.ResolveTime = time.Unix(0, rpcAttempt.ResolveTimeNs)
+	}
+
+	if rpcAttempt.Failure != nil {
+		attempt.Failure = &HtlcFailure{
+			Code:               rpcAttempt.Failure.Code,
+			FailureSourceIndex: rpcAttempt.Failure.FailureSourceIndex,
+			HtlcMsat: lnwire.MilliSatoshi(
+				rpcAttempt.Failure.HtlcMsat,
+			),
+			Height: rpcAttempt.Failure.Height,
+		}
+	}
+
+	return attempt, nil
+}
+
 // trackPayment takes an update stream from either a SendPayment or a
 // TrackPayment rpc call and converts it into distinct update and error streams.
 // Once the payment reaches a final state, the status and error channels will
@@ -233,7 +1037,10 @@ func (r *routerClient) trackPayment(ctx context.Context,
 
 	statusChan := make(chan PaymentStatus)
 	errorChan := make(chan error, 1)
+
+	r.wg.Add(1)
 	go func() {
+		defer r.wg.Done()
 		for {
 			payment, err := stream.Recv()
 			if err != nil {
@@ -275,6 +1082,8 @@ func (r *routerClient) trackPayment(ctx context.Context,
 			case statusChan <- *status:
 			case <-ctx.Done():
 				return
+			case <-r.quit:
+				return
 			}
 		}
 	}()
@@ -365,3 +1174,47 @@ func marshallHopHint(hint zpay32.HopHint) (*lnrpc.HopHint, error) {
 		NodeId:                    nodeID.String(),
 	}, nil
 }
+
+// unmarshallRouteHints unmarshalls a list of rpc route hints.
+func unmarshallRouteHints(rpcRouteHints []*lnrpc.RouteHint) (
+	[][]zpay32.HopHint, error) {
+
+	routeHints := make([][]zpay32.HopHint, 0, len(rpcRouteHints))
+	for _, rpcRouteHint := range rpcRouteHints {
+		routeHint := make(
+			[]zpay32.HopHint, 0, len(rpcRouteHint.HopHints),
+		)
+		for _, rpcHint := range rpcRouteHint.HopHints {
+			hint, err := unmarshallHopHint(rpcHint)
+			if err != nil {
+				return nil, err
+			}
+
+			routeHint = append(routeHint, hint)
+		}
+		routeHints = append(routeHints, routeHint)
+	}
+
+	return routeHints, nil
+}
+
+// unmarshallHopHint unmarshalls a single rpc hop hint.
+func unmarshallHopHint(rpcHint *lnrpc.HopHint) (zpay32.HopHint, error) {
+	pubBytes, err := hex.DecodeString(rpcHint.NodeId)
+	if err != nil {
+		return zpay32.HopHint{}, err
+	}
+
+	pubkey, err := btcec.ParsePubKey(pubBytes, btcec.S256())
+	if err != nil {
+		return zpay32.HopHint{}, err
+	}
+
+	return zpay32.HopHint{
+		NodeID:                    pubkey,
+		ChannelID:                 rpcHint.ChanId,
+		FeeBaseMSat:               rpcHint.FeeBaseMsat,
+		FeeProportionalMillionths: rpcHint.FeeProportionalMillionths,
+		CLTVExpiryDelta:           uint16(rpcHint.CltvExpiryDelta),
+	}, nil
+}
@@ -14,6 +14,11 @@ import (
 
 // SignerClient exposes sign functionality.
 type SignerClient interface {
+	// SignOutputRaw is a method that can be used to generate a signature
+	// for a set of inputs/outputs to a transaction. Each request specifies
+	// details concerning how the outputs should be signed, which keys they
+	// should be signed with, and also any optional tweaks. The returned
+	// signatures are raw DER encoded, without the sighash flag appended.
 	SignOutputRaw(ctx context.Context, tx *wire.MsgTx,
 		signDescriptors []*SignDescriptor) ([][]byte, error)
 
@@ -294,10 +299,15 @@ func (s *signerClient) DeriveSharedKey(ctx context.Context,
 
 	rpcIn := &signrpc.SharedKeyRequest{
 		EphemeralPubkey: ephemeralPubKey.SerializeCompressed(),
-		KeyLoc: &signrpc.KeyLocator{
+	}
+
+	// Only set the key locator if one is provided, lnd falls back to the
+	// node's identity key otherwise.
+	if keyLocator != nil {
+		rpcIn.KeyLoc = &signrpc.KeyLocator{
 			KeyFamily: int32(keyLocator.Family),
 			KeyIndex:  int32(keyLocator.Index),
-		},
+		}
 	}
 
 	rpcCtx = s.signerMac.WithMacaroonAuth(rpcCtx)
@@ -0,0 +1,132 @@
+package lndclient
+
+import (
+	"context"
+	"sync"
+)
+
+// NodeSnapshot holds the state of our node as returned by a single call to
+// Snapshot.
+type NodeSnapshot struct {
+	// Info is the general info of our node.
+	Info *Info
+
+	// Channels are our open channels.
+	Channels []ChannelInfo
+
+	// PendingChannels are our pending channels.
+	PendingChannels *PendingChannels
+
+	// ClosedChannels are our closed channels.
+	ClosedChannels []ClosedChannel
+
+	// Peers are the peers we are currently connected to.
+	Peers []Peer
+
+	// WalletBalance is the on-chain balance of our wallet.
+	WalletBalance *WalletBalance
+
+	// ChannelBalance is our balance in open and pending channels.
+	ChannelBalance *ChannelBalance
+
+	// FeeReport holds the fee policies of our channels and the fees we
+	// earned.
+	FeeReport *FeeReport
+}
+
+// Snapshot fetches the state of our node with concurrent calls, so that the
+// latency of the snapshot is that of the slowest call rather than the sum of
+// all calls. As lnd doesn't offer a way to query all of them atomically, the
+// results may be slightly inconsistent if the state of the node changes while
+// the snapshot is taken. If any call fails, the remaining calls are canceled
+// and the first error is returned.
+func (s *LndServices) Snapshot(ctx context.Context) (*NodeSnapshot, error) {
+	return takeSnapshot(ctx, s.Client)
+}
+
+// takeSnapshot fetches the node snapshot with concurrent calls to the client.
+func takeSnapshot(ctx context.Context, client LightningClient) (*NodeSnapshot,
+	error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		snapshot NodeSnapshot
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	// fetch runs the call in a goroutine. Each call writes to its own
+	// field of the snapshot, so no locking is needed.
+	fetch := func(call func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := call(); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+
+	fetch(func() error {
+		var err error
+		snapshot.Info, err = client.GetInfo(ctx)
+		return err
+	})
+
+	fetch(func() error {
+		var err error
+		snapshot.Channels, err = client.ListChannels(ctx)
+		return err
+	})
+
+	fetch(func() error {
+		var err error
+		snapshot.PendingChannels, err = client.PendingChannels(ctx)
+		return err
+	})
+
+	fetch(func() error {
+		var err error
+		snapshot.ClosedChannels, err = client.ClosedChannels(ctx)
+		return err
+	})
+
+	fetch(func() error {
+		var err error
+		snapshot.Peers, err = client.ListPeers(ctx)
+		return err
+	})
+
+	fetch(func() error {
+		var err error
+		snapshot.WalletBalance, err = client.WalletBalance(ctx)
+		return err
+	})
+
+	fetch(func() error {
+		var err error
+		snapshot.ChannelBalance, err = client.ChannelBalance(ctx)
+		return err
+	})
+
+	fetch(func() error {
+		var err error
+		snapshot.FeeReport, err = client.FeeReport(ctx)
+		return err
+	})
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,110 @@
+package lndclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// snapshotClient is a lightning client that serves the calls of a snapshot.
+// If err is set, PendingChannels fails with it and all other calls block
+// until they are canceled.
+type snapshotClient struct {
+	LightningClient
+
+	err error
+}
+
+// wait blocks until the context is canceled if the client is set up to fail.
+func (c *snapshotClient) wait(ctx context.Context) error {
+	if c.err == nil {
+		return nil
+	}
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *snapshotClient) GetInfo(ctx context.Context) (*Info, error) {
+	return &Info{Alias: "alias"}, c.wait(ctx)
+}
+
+func (c *snapshotClient) ListChannels(ctx context.Context,
+	_ ...ListChannelsOption) ([]ChannelInfo, error) {
+
+	return []ChannelInfo{{ChannelID: 1}}, c.wait(ctx)
+}
+
+func (c *snapshotClient) PendingChannels(_ context.Context) (*PendingChannels,
+	error) {
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &PendingChannels{}, nil
+}
+
+func (c *snapshotClient) ClosedChannels(ctx context.Context,
+	_ ...CloseType) ([]ClosedChannel, error) {
+
+	return []ClosedChannel{{ChannelID: 2}}, c.wait(ctx)
+}
+
+func (c *snapshotClient) ListPeers(ctx context.Context) ([]Peer, error) {
+	return []Peer{{}}, c.wait(ctx)
+}
+
+func (c *snapshotClient) WalletBalance(ctx context.Context) (*WalletBalance,
+	error) {
+
+	return &WalletBalance{Confirmed: 1000}, c.wait(ctx)
+}
+
+func (c *snapshotClient) ChannelBalance(ctx context.Context) (
+	*ChannelBalance, error) {
+
+	return &ChannelBalance{Balance: 2000}, c.wait(ctx)
+}
+
+func (c *snapshotClient) FeeReport(ctx context.Context) (*FeeReport, error) {
+	return &FeeReport{}, c.wait(ctx)
+}
+
+// TestTakeSnapshot tests that a snapshot holds the results of all calls and
+// that a failing call cancels the others and fails the snapshot.
+func TestTakeSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	snapshot, err := takeSnapshot(ctx, &snapshotClient{})
+	if err != nil {
+		t.Fatalf("unable to take snapshot: %v", err)
+	}
+
+	if snapshot.Info.Alias != "alias" {
+		t.Fatalf("unexpected info: %v", snapshot.Info)
+	}
+	if len(snapshot.Channels) != 1 || len(snapshot.ClosedChannels) != 1 ||
+		len(snapshot.Peers) != 1 {
+
+		t.Fatalf("unexpected channels or peers: %+v", snapshot)
+	}
+	if snapshot.PendingChannels == nil || snapshot.FeeReport == nil {
+		t.Fatalf("expected pending channels and fee report")
+	}
+	if snapshot.WalletBalance.Confirmed != 1000 ||
+		snapshot.ChannelBalance.Balance != 2000 {
+
+		t.Fatalf("unexpected balances: %v, %v",
+			snapshot.WalletBalance, snapshot.ChannelBalance)
+	}
+
+	// The other calls only return once they are canceled, so the
+	// snapshot only completes if the failure cancels them. The first
+	// error is returned rather than the cancellation errors.
+	callErr := errors.New("pending channels failed")
+	_, err = takeSnapshot(ctx, &snapshotClient{err: callErr})
+	if err != callErr {
+		t.Fatalf("expected error %v, got %v", callErr, err)
+	}
+}
@@ -3,7 +3,9 @@ package lndclient
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -18,6 +20,8 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // WalletKitClient exposes wallet functionality.
@@ -49,10 +53,19 @@ type WalletKitClient interface {
 
 	NextAddr(ctx context.Context) (btcutil.Address, error)
 
-	PublishTransaction(ctx context.Context, tx *wire.MsgTx) error
+	// PublishTransaction attempts to publish the passed transaction to the
+	// network. The label is stored with the transaction in the wallet and
+	// may be empty. If the backend rejects the transaction, a
+	// *PublishError holding the rejection reason is returned.
+	PublishTransaction(ctx context.Context, tx *wire.MsgTx,
+		label string) error
 
+	// SendOutputs creates and broadcasts a transaction that pays to the
+	// given outputs, at the given fee rate. The label is stored with the
+	// transaction in the wallet and may be empty.
 	SendOutputs(ctx context.Context, outputs []*wire.TxOut,
-		feeRate chainfee.SatPerKWeight) (*wire.MsgTx, error)
+		feeRate chainfee.SatPerKWeight, label string) (*wire.MsgTx,
+		error)
 
 	EstimateFee(ctx context.Context, confTarget int32) (chainfee.SatPerKWeight,
 		error)
@@ -61,6 +74,67 @@ type WalletKitClient interface {
 	// Note that this function only looks up transaction ids, and does not
 	// query our wallet for the full set of transactions.
 	ListSweeps(ctx context.Context) ([]string, error)
+
+	// ListSweepsVerbose returns the full transactions of all sweeps known
+	// to our node.
+	ListSweepsVerbose(ctx context.Context) ([]Transaction, error)
+
+	// BumpFee attempts to bump the fee of the transaction that spends or
+	// creates the given outpoint. For an unconfirmed output of a wallet
+	// transaction, a child transaction is created to bump the fee (CPFP).
+	// Either a confirmation target or a fee rate must be set through the
+	// options.
+	BumpFee(ctx context.Context, outpoint wire.OutPoint,
+		opts ...BumpFeeOption) error
+
+	// LabelTransaction adds a label to a transaction in our wallet. If the
+	// transaction already has a label, overwrite must be set to replace it.
+	LabelTransaction(ctx context.Context, txid chainhash.Hash, label string,
+		overwrite bool) error
+}
+
+// BumpFeeOption is a functional option argument that allows setting the
+// parameters of a fee bump. These are always processed in order, with later
+// options overriding earlier ones.
+type BumpFeeOption func(r *walletrpc.BumpFeeRequest)
+
+// WithBumpTargetConf is a bump fee option that sets the number of blocks the
+// input should confirm in. This is used for fee estimation.
+func WithBumpTargetConf(targetConf uint32) BumpFeeOption {
+	return func(r *walletrpc.BumpFeeRequest) {
+		r.TargetConf = targetConf
+		r.SatPerByte = 0
+	}
+}
+
+// WithBumpSatPerVByte is a bump fee option that sets a manual fee rate in
+// sat/vbyte for the input.
+func WithBumpSatPerVByte(satPerVByte uint32) BumpFeeOption {
+	return func(r *walletrpc.BumpFeeRequest) {
+		r.SatPerByte = satPerVByte
+		r.TargetConf = 0
+	}
+}
+
+// WithBumpForce is a bump fee option that makes the sweeper sweep the input
+// even if it is uneconomical to do so.
+func WithBumpForce() BumpFeeOption {
+	return func(r *walletrpc.BumpFeeRequest) {
+		r.Force = true
+	}
+}
+
+// PublishError is returned by PublishTransaction if the transaction was
+// rejected by the chain backend.
+type PublishError struct {
+	// Reason is the string representation of the broadcast error as
+	// reported by lnd.
+	Reason string
+}
+
+// Error returns the publish error as a string.
+func (e *PublishError) Error() string {
+	return fmt.Sprintf("publish transaction: %v", e.Reason)
 }
 
 type walletKitClient struct {
@@ -256,8 +330,12 @@ func (m *walletKitClient) NextAddr(ctx context.Context) (
 	return addr, nil
 }
 
+// PublishTransaction attempts to publish the passed transaction to the
+// network. The label is stored with the transaction in the wallet and may be
+// empty. If the backend rejects the transaction, a *PublishError holding the
+// rejection reason is returned.
 func (m *walletKitClient) PublishTransaction(ctx context.Context,
-	tx *wire.MsgTx) error {
+	tx *wire.MsgTx, label string) error {
 
 	txHex, err := encodeTx(tx)
 	if err != nil {
@@ -270,14 +348,60 @@ func (m *walletKitClient) PublishTransaction(ctx context.Context,
 	rpcCtx = m.walletKitMac.WithMacaroonAuth(rpcCtx)
 	_, err = m.client.PublishTransaction(rpcCtx, &walletrpc.Transaction{
 		TxHex: txHex,
+		Label: label,
 	})
 
+	return publishErr(err)
+}
+
+// publishRejections are substrings of the errors that lnd returns if the
+// chain backend rejected a published transaction.
+var publishRejections = []string{
+	// lnd's own error for transactions that double spend an output.
+	"output already spent",
+
+	// bitcoind and btcd reject transactions with one of the json-rpc
+	// verification error codes, which prefix the message.
+	"-25: ", "-26: ", "-27: ",
+
+	// Rejections that neutrino received from its peers are prefixed with
+	// the rejection code.
+	"Invalid: ", "InsufficientFee: ", "Mempool: ", "Confirmed: ",
+	"DoubleSpend: ",
+}
+
+// publishErr converts the error returned by lnd's PublishTransaction into a
+// *PublishError if the chain backend rejected the transaction. lnd returns
+// rejections as plain errors with the unknown status code, so we match the
+// message. All other errors, such as invalid labels, connection problems or
+// missing permissions, are returned unchanged.
+func publishErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	rpcStatus, ok := status.FromError(err)
+	if !ok || rpcStatus.Code() != codes.Unknown {
+		return err
+	}
+
+	for _, rejection := range publishRejections {
+		if strings.Contains(rpcStatus.Message(), rejection) {
+			return &PublishError{
+				Reason: rpcStatus.Message(),
+			}
+		}
+	}
+
 	return err
 }
 
+// SendOutputs creates and broadcasts a transaction that pays to the given
+// outputs, at the given fee rate. The label is stored with the transaction in
+// the wallet and may be empty.
 func (m *walletKitClient) SendOutputs(ctx context.Context,
-	outputs []*wire.TxOut, feeRate chainfee.SatPerKWeight) (
-	*wire.MsgTx, error) {
+	outputs []*wire.TxOut, feeRate chainfee.SatPerKWeight,
+	label string) (*wire.MsgTx, error) {
 
 	rpcOutputs := make([]*signrpc.TxOut, len(outputs))
 	for i, output := range outputs {
@@ -294,6 +418,7 @@ func (m *walletKitClient) SendOutputs(ctx context.Context,
 	resp, err := m.client.SendOutputs(rpcCtx, &walletrpc.SendOutputsRequest{
 		Outputs:  rpcOutputs,
 		SatPerKw: int64(feeRate),
+		Label:    label,
 	})
 	if err != nil {
 		return nil, err
@@ -346,3 +471,91 @@ func (m *walletKitClient) ListSweeps(ctx context.Context) ([]string, error) {
 	sweeps := resp.GetTransactionIds()
 	return sweeps.TransactionIds, nil
 }
+
+// ListSweepsVerbose returns the full transactions of all sweeps known to our
+// node. The transactions are looked up in our wallet, so this call is more
+// expensive than ListSweeps.
+func (m *walletKitClient) ListSweepsVerbose(ctx context.Context) ([]Transaction,
+	error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	resp, err := m.client.ListSweeps(
+		m.walletKitMac.WithMacaroonAuth(rpcCtx),
+		&walletrpc.ListSweepsRequest{
+			Verbose: true,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	details := resp.GetTransactionDetails()
+	if details == nil {
+		return nil, errors.New("verbose sweep response expected")
+	}
+
+	sweeps := make([]Transaction, len(details.Transactions))
+	for i, rpcTx := range details.Transactions {
+		tx, err := unmarshalTransaction(rpcTx)
+		if err != nil {
+			return nil, err
+		}
+
+		sweeps[i] = *tx
+	}
+
+	return sweeps, nil
+}
+
+// BumpFee attempts to bump the fee of the transaction that spends or creates
+// the given outpoint. For an unconfirmed output of a wallet transaction, a
+// child transaction is created to bump the fee (CPFP). Either a confirmation
+// target or a fee rate must be set through the options.
+func (m *walletKitClient) BumpFee(ctx context.Context, outpoint wire.OutPoint,
+	opts ...BumpFeeOption) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcReq := &walletrpc.BumpFeeRequest{
+		Outpoint: &lnrpc.OutPoint{
+			TxidBytes:   outpoint.Hash[:],
+			OutputIndex: outpoint.Index,
+		},
+	}
+	for _, opt := range opts {
+		opt(rpcReq)
+	}
+
+	if rpcReq.TargetConf == 0 && rpcReq.SatPerByte == 0 {
+		return errors.New("either target conf or fee rate must be set")
+	}
+
+	rpcCtx = m.walletKitMac.WithMacaroonAuth(rpcCtx)
+	_, err := m.client.BumpFee(rpcCtx, rpcReq)
+
+	return err
+}
+
+// LabelTransaction adds a label to a transaction in our wallet. If the
+// transaction already has a label, overwrite must be set to replace it. The
+// label is returned in the Label field of ListTransactions.
+func (m *walletKitClient) LabelTransaction(ctx context.Context,
+	txid chainhash.Hash, label string, overwrite bool) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = m.walletKitMac.WithMacaroonAuth(rpcCtx)
+	_, err := m.client.LabelTransaction(
+		rpcCtx, &walletrpc.LabelTransactionRequest{
+			Txid:      txid[:],
+			Label:     label,
+			Overwrite: overwrite,
+		},
+	)
+
+	return err
+}
@@ -0,0 +1,106 @@
+package lndclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// publishWalletKit is a wallet kit rpc client that fails publishing with a
+// fixed error.
+type publishWalletKit struct {
+	walletrpc.WalletKitClient
+
+	err error
+}
+
+func (p *publishWalletKit) PublishTransaction(context.Context,
+	*walletrpc.Transaction, ...grpc.CallOption) (*walletrpc.PublishResponse,
+	error) {
+
+	return &walletrpc.PublishResponse{}, p.err
+}
+
+// TestPublishTransactionError tests that rejections of a transaction are
+// returned as a publish error, while other errors are returned unchanged.
+func TestPublishTransactionError(t *testing.T) {
+	denied := status.Error(codes.Unknown, "verification failed: "+
+		"signature mismatch after caveat verification")
+
+	testCases := []struct {
+		name   string
+		err    error
+		reason string
+	}{{
+		name: "published",
+	}, {
+		name: "rejected by bitcoind",
+		err: status.Error(
+			codes.Unknown, "-26: txn-mempool-conflict",
+		),
+		reason: "-26: txn-mempool-conflict",
+	}, {
+		name: "double spend",
+		err: status.Error(codes.Unknown, "Transaction rejected: "+
+			"output already spent"),
+		reason: "Transaction rejected: output already spent",
+	}, {
+		name: "rejected by neutrino peers",
+		err: status.Error(
+			codes.Unknown, "InsufficientFee: min relay fee not met",
+		),
+		reason: "InsufficientFee: min relay fee not met",
+	}, {
+		name: "invalid label",
+		err: status.Error(
+			codes.Unknown, "label exceeds limit of 500 characters",
+		),
+	}, {
+		name: "permission denied",
+		err: &RPCError{
+			Kind: ErrPermissionDenied,
+			err:  denied,
+		},
+	}, {
+		name: "unavailable",
+		err:  status.Error(codes.Unavailable, "connection refused"),
+	}}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &walletKitClient{
+				client: &publishWalletKit{err: testCase.err},
+			}
+
+			err := client.PublishTransaction(
+				context.Background(), wire.NewMsgTx(2), "",
+			)
+
+			var publishErr *PublishError
+			switch {
+			case testCase.reason != "":
+				if !errors.As(err, &publishErr) {
+					t.Fatalf("expected publish error, "+
+						"got: %v", err)
+				}
+				if publishErr.Reason != testCase.reason {
+					t.Fatalf("expected reason %v, got %v",
+						testCase.reason,
+						publishErr.Reason)
+				}
+
+			case err != testCase.err:
+				t.Fatalf("expected error %v, got %v",
+					testCase.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package lndclient
+
+import (
+	"context"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+)
+
+// WalletUnlockerClient exposes the wallet creation functionality of lnd that
+// is available before the wallet is initialized.
+type WalletUnlockerClient interface {
+	// GenSeed generates a new aezeed cipher seed that can be used to
+	// initialize the wallet. The passphrase is optional and encrypts the
+	// seed. If no entropy is provided, lnd generates it. The mnemonic and
+	// the enciphered seed are returned.
+	GenSeed(ctx context.Context, aezeedPassphrase,
+		seedEntropy []byte) ([]string, []byte, error)
+
+	// InitWallet initializes lnd's wallet with the given password and
+	// seed.
+	InitWallet(ctx context.Context, req *InitWalletRequest) error
+}
+
+// InitWalletRequest contains the parameters to initialize lnd's wallet.
+type InitWalletRequest struct {
+	// WalletPassword is the password that encrypts the wallet on disk.
+	// It must be at least 8 characters long.
+	WalletPassword []byte
+
+	// SeedMnemonic is the 24-word aezeed mnemonic the wallet is created
+	// from, as returned by GenSeed.
+	SeedMnemonic []string
+
+	// AezeedPassphrase is the optional passphrase that was used to
+	// encrypt the seed.
+	AezeedPassphrase []byte
+
+	// RecoveryWindow is the address look-ahead used when restoring a
+	// wallet from an existing seed. If zero, no on-chain funds are
+	// recovered.
+	RecoveryWindow int32
+
+	// MultiChanBackup is an optional packed multi channel backup. If set,
+	// the channels in the backup are restored once the wallet is
+	// initialized.
+	MultiChanBackup []byte
+}
+
+type walletUnlockerClient struct {
+	client lnrpc.WalletUnlockerClient
+}
+
+// A compile-time constraint to ensure walletUnlockerClient satisfies the
+// WalletUnlockerClient interface.
+var _ WalletUnlockerClient = (*walletUnlockerClient)(nil)
+
+// NewWalletUnlockerClient creates a client for lnd's wallet unlocker service.
+// The service is only available while lnd's wallet is not yet unlocked and
+// does not require a macaroon, so the connection can be created with
+// NewBasicConn before any macaroons exist.
+func NewWalletUnlockerClient(conn *grpc.ClientConn) WalletUnlockerClient {
+	return &walletUnlockerClient{
+		client: lnrpc.NewWalletUnlockerClient(conn),
+	}
+}
+
+// GenSeed generates a new aezeed cipher seed that can be used to initialize
+// the wallet. The passphrase is optional and encrypts the seed. If no entropy
+// is provided, lnd generates it. The mnemonic and the enciphered seed are
+// returned.
+func (w *walletUnlockerClient) GenSeed(ctx context.Context, aezeedPassphrase,
+	seedEntropy []byte) ([]string, []byte, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	resp, err := w.client.GenSeed(rpcCtx, &lnrpc.GenSeedRequest{
+		AezeedPassphrase: aezeedPassphrase,
+		SeedEntropy:      seedEntropy,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.CipherSeedMnemonic, resp.EncipheredSeed, nil
+}
+
+// InitWallet initializes lnd's wallet with the given password and seed.
+//
+// NOTE: The extended master key, watch-only and stateless init options as
+// well as the admin macaroon in the response are only available in lnd v0.13
+// and later.
+func (w *walletUnlockerClient) InitWallet(ctx context.Context,
+	req *InitWalletRequest) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcReq := &lnrpc.InitWalletRequest{
+		WalletPassword:     req.WalletPassword,
+		CipherSeedMnemonic: req.SeedMnemonic,
+		AezeedPassphrase:   req.AezeedPassphrase,
+		RecoveryWindow:     req.RecoveryWindow,
+	}
+
+	if len(req.MultiChanBackup) > 0 {
+		rpcReq.ChannelBackups = &lnrpc.ChanBackupSnapshot{
+			MultiChanBackup: &lnrpc.MultiChanBackup{
+				MultiChanBackup: req.MultiChanBackup,
+			},
+		}
+	}
+
+	_, err := w.client.InitWallet(rpcCtx, rpcReq)
+
+	return err
+}
@@ -0,0 +1,284 @@
+package lndclient
+
+import (
+	"context"
+
+	"github.com/lightningnetwork/lnd/lnrpc/wtclientrpc"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"google.golang.org/grpc"
+)
+
+// WatchtowerClient exposes the watchtower client functionality of lnd. The
+// connected lnd node needs to be built with the wtclientrpc build tag, see
+// FeatureWatchtowerClient.
+type WatchtowerClient interface {
+	// AddTower adds a new watchtower reachable at the given address and
+	// considers it for new sessions. If the tower already exists, the
+	// address is added to its list of addresses.
+	AddTower(ctx context.Context, pubkey route.Vertex,
+		address string) error
+
+	// RemoveTower removes a watchtower from being considered for future
+	// sessions. If an address is given, only that address is removed from
+	// the tower instead.
+	RemoveTower(ctx context.Context, pubkey route.Vertex,
+		address string) error
+
+	// ListTowers returns the list of watchtowers registered with the
+	// client.
+	ListTowers(ctx context.Context, includeSessions bool) ([]Tower, error)
+
+	// GetTowerInfo returns information about a registered watchtower.
+	GetTowerInfo(ctx context.Context, pubkey route.Vertex,
+		includeSessions bool) (*Tower, error)
+
+	// Stats returns the in-memory statistics of the watchtower client
+	// since startup.
+	Stats(ctx context.Context) (*TowerClientStats, error)
+
+	// Policy returns the active watchtower client policy configuration.
+	Policy(ctx context.Context) (*TowerPolicy, error)
+}
+
+// Tower describes a watchtower registered with the watchtower client.
+type Tower struct {
+	// PubKey is the identity public key of the watchtower.
+	PubKey route.Vertex
+
+	// Addresses is the list of addresses the watchtower is reachable
+	// over.
+	Addresses []string
+
+	// ActiveSessionCandidate indicates whether the watchtower is being
+	// considered for new sessions.
+	ActiveSessionCandidate bool
+
+	// NumSessions is the number of sessions that have been negotiated
+	// with the watchtower.
+	NumSessions uint32
+
+	// Sessions is the list of sessions with the watchtower. It is only
+	// populated if sessions were requested.
+	Sessions []TowerSession
+}
+
+// TowerSession describes a session negotiated with a watchtower.
+type TowerSession struct {
+	// NumBackups is the total number of backups in the session that have
+	// been acknowledged by the watchtower.
+	NumBackups uint32
+
+	// NumPendingBackups is the total number of backups in the session
+	// that are queued but not yet acknowledged.
+	NumPendingBackups uint32
+
+	// MaxBackups is the maximum number of backups allowed by the session.
+	MaxBackups uint32
+
+	// SweepSatPerVByte is the fee rate in sat/vbyte that is used by the
+	// watchtower to sweep our funds in case of a breach.
+	SweepSatPerVByte uint32
+}
+
+// TowerClientStats contains the statistics of the watchtower client.
+type TowerClientStats struct {
+	// NumBackups is the total number of backups made to all active and
+	// exhausted watchtower sessions.
+	NumBackups uint32
+
+	// NumPendingBackups is the total number of backups that are pending
+	// to be acknowledged by all active and exhausted sessions.
+	NumPendingBackups uint32
+
+	// NumFailedBackups is the total number of backups that all active and
+	// exhausted sessions rejected.
+	NumFailedBackups uint32
+
+	// NumSessionsAcquired is the total number of new sessions that were
+	// acquired.
+	NumSessionsAcquired uint32
+
+	// NumSessionsExhausted is the total number of sessions that were
+	// exhausted.
+	NumSessionsExhausted uint32
+}
+
+// TowerPolicy contains the policy the watchtower client uses for new
+// sessions.
+type TowerPolicy struct {
+	// MaxUpdates is the maximum number of updates in a single session.
+	MaxUpdates uint32
+
+	// SweepSatPerVByte is the fee rate in sat/vbyte that is used by the
+	// watchtower to sweep our funds in case of a breach.
+	SweepSatPerVByte uint32
+}
+
+type watchtowerClient struct {
+	client   wtclientrpc.WatchtowerClientClient
+	adminMac serializedMacaroon
+}
+
+// A compile-time constraint to ensure watchtowerClient satisfies the
+// WatchtowerClient interface.
+var _ WatchtowerClient = (*watchtowerClient)(nil)
+
+func newWatchtowerClient(conn *grpc.ClientConn,
+	adminMac serializedMacaroon) *watchtowerClient {
+
+	return &watchtowerClient{
+		client:   wtclientrpc.NewWatchtowerClientClient(conn),
+		adminMac: adminMac,
+	}
+}
+
+// AddTower adds a new watchtower reachable at the given address and considers
+// it for new sessions. If the tower already exists, the address is added to
+// its list of addresses.
+func (w *watchtowerClient) AddTower(ctx context.Context, pubkey route.Vertex,
+	address string) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = w.adminMac.WithMacaroonAuth(rpcCtx)
+	_, err := w.client.AddTower(rpcCtx, &wtclientrpc.AddTowerRequest{
+		Pubkey:  pubkey[:],
+		Address: address,
+	})
+
+	return err
+}
+
+// RemoveTower removes a watchtower from being considered for future sessions.
+// If an address is given, only that address is removed from the tower
+// instead.
+func (w *watchtowerClient) RemoveTower(ctx context.Context,
+	pubkey route.Vertex, address string) error {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = w.adminMac.WithMacaroonAuth(rpcCtx)
+	_, err := w.client.RemoveTower(rpcCtx, &wtclientrpc.RemoveTowerRequest{
+		Pubkey:  pubkey[:],
+		Address: address,
+	})
+
+	return err
+}
+
+// ListTowers returns the list of watchtowers registered with the client.
+func (w *watchtowerClient) ListTowers(ctx context.Context,
+	includeSessions bool) ([]Tower, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = w.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := w.client.ListTowers(rpcCtx, &wtclientrpc.ListTowersRequest{
+		IncludeSessions: includeSessions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	towers := make([]Tower, len(resp.Towers))
+	for i, rpcTower := range resp.Towers {
+		tower, err := unmarshalTower(rpcTower)
+		if err != nil {
+			return nil, err
+		}
+
+		towers[i] = *tower
+	}
+
+	return towers, nil
+}
+
+// GetTowerInfo returns information about a registered watchtower.
+func (w *watchtowerClient) GetTowerInfo(ctx context.Context,
+	pubkey route.Vertex, includeSessions bool) (*Tower, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = w.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := w.client.GetTowerInfo(
+		rpcCtx, &wtclientrpc.GetTowerInfoRequest{
+			Pubkey:          pubkey[:],
+			IncludeSessions: includeSessions,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalTower(resp)
+}
+
+// Stats returns the in-memory statistics of the watchtower client since
+// startup.
+func (w *watchtowerClient) Stats(ctx context.Context) (*TowerClientStats,
+	error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = w.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := w.client.Stats(rpcCtx, &wtclientrpc.StatsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TowerClientStats{
+		NumBackups:           resp.NumBackups,
+		NumPendingBackups:    resp.NumPendingBackups,
+		NumFailedBackups:     resp.NumFailedBackups,
+		NumSessionsAcquired:  resp.NumSessionsAcquired,
+		NumSessionsExhausted: resp.NumSessionsExhausted,
+	}, nil
+}
+
+// Policy returns the active watchtower client policy configuration.
+func (w *watchtowerClient) Policy(ctx context.Context) (*TowerPolicy, error) {
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = w.adminMac.WithMacaroonAuth(rpcCtx)
+	resp, err := w.client.Policy(rpcCtx, &wtclientrpc.PolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TowerPolicy{
+		MaxUpdates:       resp.MaxUpdates,
+		SweepSatPerVByte: resp.SweepSatPerByte,
+	}, nil
+}
+
+// unmarshalTower unmarshals an rpc watchtower.
+func unmarshalTower(rpcTower *wtclientrpc.Tower) (*Tower, error) {
+	pubkey, err := route.NewVertexFromBytes(rpcTower.Pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]TowerSession, len(rpcTower.Sessions))
+	for i, session := range rpcTower.Sessions {
+		sessions[i] = TowerSession{
+			NumBackups:        session.NumBackups,
+			NumPendingBackups: session.NumPendingBackups,
+			MaxBackups:        session.MaxBackups,
+			SweepSatPerVByte:  session.SweepSatPerByte,
+		}
+	}
+
+	return &Tower{
+		PubKey:                 pubkey,
+		Addresses:              rpcTower.Addresses,
+		ActiveSessionCandidate: rpcTower.ActiveSessionCandidate,
+		NumSessions:            rpcTower.NumSessions,
+		Sessions:               sessions,
+	}, nil
+}
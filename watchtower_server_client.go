@@ -0,0 +1,74 @@
+package lndclient
+
+import (
+	"context"
+
+	"github.com/lightningnetwork/lnd/lnrpc/watchtowerrpc"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"google.golang.org/grpc"
+)
+
+// WatchtowerServerClient exposes the information of the watchtower that is
+// run by lnd. The connected lnd node needs to be built with the watchtowerrpc
+// build tag and have its watchtower enabled, see FeatureWatchtowerServer.
+type WatchtowerServerClient interface {
+	// GetInfo returns information about the watchtower run by lnd.
+	GetInfo(ctx context.Context) (*WatchtowerInfo, error)
+}
+
+// WatchtowerInfo contains the information that clients need to connect to the
+// watchtower run by lnd.
+type WatchtowerInfo struct {
+	// PubKey is the identity public key of the watchtower.
+	PubKey route.Vertex
+
+	// Listeners is the list of addresses the watchtower is listening on.
+	Listeners []string
+
+	// URIs is the list of public URIs of the watchtower, in the form
+	// pubkey@host:port.
+	URIs []string
+}
+
+type watchtowerServerClient struct {
+	client      watchtowerrpc.WatchtowerClient
+	readonlyMac serializedMacaroon
+}
+
+// A compile-time constraint to ensure watchtowerServerClient satisfies the
+// WatchtowerServerClient interface.
+var _ WatchtowerServerClient = (*watchtowerServerClient)(nil)
+
+func newWatchtowerServerClient(conn *grpc.ClientConn,
+	readonlyMac serializedMacaroon) *watchtowerServerClient {
+
+	return &watchtowerServerClient{
+		client:      watchtowerrpc.NewWatchtowerClient(conn),
+		readonlyMac: readonlyMac,
+	}
+}
+
+// GetInfo returns information about the watchtower run by lnd.
+func (w *watchtowerServerClient) GetInfo(ctx context.Context) (
+	*WatchtowerInfo, error) {
+
+	rpcCtx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	rpcCtx = w.readonlyMac.WithMacaroonAuth(rpcCtx)
+	resp, err := w.client.GetInfo(rpcCtx, &watchtowerrpc.GetInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	pubkey, err := route.NewVertexFromBytes(resp.Pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WatchtowerInfo{
+		PubKey:    pubkey,
+		Listeners: resp.Listeners,
+		URIs:      resp.Uris,
+	}, nil
+}